@@ -0,0 +1,7 @@
+data "garage_access_key" "by_id" {
+  id = "GK31c2f218a2e44f485b94239e"
+}
+
+data "garage_access_key" "by_name" {
+  search = "bongo"
+}
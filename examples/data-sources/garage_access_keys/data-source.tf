@@ -0,0 +1 @@
+data "garage_access_keys" "example" {}
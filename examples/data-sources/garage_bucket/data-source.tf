@@ -0,0 +1,7 @@
+data "garage_bucket" "by_alias" {
+  global_alias = "bongo"
+}
+
+data "garage_bucket" "by_id" {
+  id = "e6a14cd6a27f48684579ec6b381c078ab11697e6bc8513b72b2f5307e25fff9b"
+}
@@ -0,0 +1,3 @@
+data "garage_buckets" "example" {
+  prefix = "backups-"
+}
@@ -0,0 +1 @@
+terraform import garage_access_key_buckets.test "{access_key_id}"
@@ -0,0 +1,24 @@
+resource "garage_access_key" "example" {
+  name = "bongo"
+}
+
+resource "garage_bucket" "data" {
+  name = "data"
+}
+
+resource "garage_bucket" "logs" {
+  name = "logs"
+}
+
+resource "garage_access_key_buckets" "example" {
+  access_key_id = garage_access_key.example.id
+  buckets = {
+    (garage_bucket.data.id) = {
+      read  = true
+      write = true
+    }
+    (garage_bucket.logs.id) = {
+      write = true
+    }
+  }
+}
@@ -1,3 +1,13 @@
 resource "garage_bucket" "example" {
   name = "bongo"
+
+  quotas = {
+    max_size    = 1073741824
+    max_objects = 10000
+  }
+
+  website = {
+    index_document = "index.html"
+    error_document = "404.html"
+  }
 }
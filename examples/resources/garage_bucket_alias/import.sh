@@ -0,0 +1 @@
+terraform import garage_bucket_alias.test "{bucket_id}:{alias}"
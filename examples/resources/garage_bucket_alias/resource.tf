@@ -0,0 +1,8 @@
+resource "garage_bucket" "example" {
+  name = "bongo"
+}
+
+resource "garage_bucket_alias" "example" {
+  bucket_id = garage_bucket.example.id
+  alias     = "bongo-v2"
+}
@@ -0,0 +1 @@
+terraform import garage_bucket_local_alias.test "{bucket_id}:{access_key_id}:{alias}"
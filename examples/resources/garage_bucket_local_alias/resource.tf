@@ -0,0 +1,17 @@
+resource "garage_access_key" "example" {
+  name          = "bongo"
+  never_expires = true
+}
+
+resource "garage_bucket" "example" {
+  local_alias = {
+    access_key_id = garage_access_key.example.id
+    alias         = "bongo"
+  }
+}
+
+resource "garage_bucket_local_alias" "example" {
+  bucket_id     = garage_bucket.example.id
+  access_key_id = garage_access_key.example.id
+  alias         = "bongo-v2"
+}
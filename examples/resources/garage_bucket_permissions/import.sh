@@ -0,0 +1 @@
+terraform import garage_bucket_permissions.test "{bucket_id}"
@@ -0,0 +1,24 @@
+resource "garage_bucket" "example" {
+  name = "bongo"
+}
+
+resource "garage_access_key" "app" {
+  name = "app"
+}
+
+resource "garage_access_key" "backup" {
+  name = "backup"
+}
+
+resource "garage_bucket_permissions" "example" {
+  bucket_id = garage_bucket.example.id
+  keys = {
+    (garage_access_key.app.id) = {
+      read  = true
+      write = true
+    }
+    (garage_access_key.backup.id) = {
+      read = true
+    }
+  }
+}
@@ -1 +1,4 @@
 terraform import garage_permission.test "{access_key_id}:{bucket_id}"
+
+# Or by a global alias of the bucket
+terraform import garage_permission.test "alias/{bucket_alias}:{access_key_id}"
@@ -13,3 +13,13 @@ resource "garage_permission" "example" {
   read          = true
   write         = true
 }
+
+resource "garage_access_key" "reader" {
+  name = "reader"
+}
+
+resource "garage_permission" "by_alias" {
+  access_key_id = garage_access_key.reader.id
+  bucket_alias  = garage_bucket.example.name
+  read          = true
+}
@@ -12,14 +12,38 @@ type AccessKey struct {
 	AccessKeyID     string  `json:"accessKeyId"`
 	SecretAccessKey *string `json:"secretAccessKey"`
 	Expiration      *string `json:"expiration"`
+	Created         *string `json:"created"`
+	Permissions     struct {
+		CreateBucket bool `json:"createBucket"`
+	} `json:"permissions"`
+	Buckets []struct {
+		ID            string   `json:"id"`
+		GlobalAliases []string `json:"globalAliases"`
+		LocalAliases  []string `json:"localAliases"`
+		Permissions   struct {
+			Owner bool `json:"owner"`
+			Read  bool `json:"read"`
+			Write bool `json:"write"`
+		} `json:"permissions"`
+	} `json:"buckets"`
 }
 
 func (c *Client) GetAccessKey(ctx context.Context, id string) (*AccessKey, error) {
+	return c.getKeyInfo(ctx, "id", id)
+}
+
+// SearchAccessKey finds a key by a partial id or name, the search must only
+// match a single key.
+func (c *Client) SearchAccessKey(ctx context.Context, search string) (*AccessKey, error) {
+	return c.getKeyInfo(ctx, "search", search)
+}
+
+func (c *Client) getKeyInfo(ctx context.Context, param, value string) (*AccessKey, error) {
 	key := &AccessKey{}
 	err := c.do(
 		ctx,
 		http.MethodGet,
-		fmt.Sprintf("/v2/GetKeyInfo?id=%s", url.QueryEscape(id)),
+		fmt.Sprintf("/v2/GetKeyInfo?%s=%s", param, url.QueryEscape(value)),
 		nil,
 		key,
 	)
@@ -29,10 +53,33 @@ func (c *Client) GetAccessKey(ctx context.Context, id string) (*AccessKey, error
 	return key, nil
 }
 
+type AccessKeyListItem struct {
+	ID         string  `json:"id"`
+	Name       string  `json:"name"`
+	Created    *string `json:"created"`
+	Expiration *string `json:"expiration"`
+	Expired    bool    `json:"expired"`
+}
+
+func (c *Client) ListAccessKeys(ctx context.Context) ([]AccessKeyListItem, error) {
+	keys := []AccessKeyListItem{}
+	err := c.do(ctx, http.MethodGet, "/v2/ListKeys", nil, &keys)
+	if err != nil {
+		return nil, fmt.Errorf("list keys: %w", err)
+	}
+	return keys, nil
+}
+
+type KeyPermissions struct {
+	CreateBucket bool `json:"createBucket"`
+}
+
 type CreateKeyRequest struct {
-	Name         string `json:"name"`
-	Expiration   string `json:"expiration,omitempty"`
-	NeverExpires bool   `json:"neverExpires,omitempty"`
+	Name         string          `json:"name"`
+	Expiration   string          `json:"expiration,omitempty"`
+	NeverExpires bool            `json:"neverExpires,omitempty"`
+	Allow        *KeyPermissions `json:"allow,omitempty"`
+	Deny         *KeyPermissions `json:"deny,omitempty"`
 }
 
 func (c *Client) CreateAccessKey(ctx context.Context, req CreateKeyRequest) (*AccessKey, error) {
@@ -44,6 +91,23 @@ func (c *Client) CreateAccessKey(ctx context.Context, req CreateKeyRequest) (*Ac
 	return key, nil
 }
 
+type ImportKeyRequest struct {
+	AccessKeyID     string `json:"accessKeyId"`
+	SecretAccessKey string `json:"secretAccessKey"`
+	Name            string `json:"name,omitempty"`
+}
+
+// ImportAccessKey creates a key with existing credentials, i.e. when migrating
+// from another s3 provider.
+func (c *Client) ImportAccessKey(ctx context.Context, req ImportKeyRequest) (*AccessKey, error) {
+	key := &AccessKey{}
+	err := c.do(ctx, http.MethodPost, "/v2/ImportKey", req, key)
+	if err != nil {
+		return nil, fmt.Errorf("import key: %w", err)
+	}
+	return key, nil
+}
+
 func (c *Client) UpdateAccessKey(
 	ctx context.Context,
 	id string,
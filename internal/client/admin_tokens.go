@@ -0,0 +1,26 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type AdminTokenInfo struct {
+	ID         *string  `json:"id"`
+	Name       string   `json:"name"`
+	Expiration *string  `json:"expiration"`
+	Expired    bool     `json:"expired"`
+	Scope      []string `json:"scope"`
+}
+
+// GetCurrentAdminToken returns the token the client authenticates with, any
+// valid token can call it so it is used to check the token works.
+func (c *Client) GetCurrentAdminToken(ctx context.Context) (*AdminTokenInfo, error) {
+	token := &AdminTokenInfo{}
+	err := c.do(ctx, http.MethodGet, "/v2/GetCurrentAdminTokenInfo", nil, token)
+	if err != nil {
+		return nil, fmt.Errorf("get current admin token: %w", err)
+	}
+	return token, nil
+}
@@ -0,0 +1,34 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// BucketAliasRequest adds/removes either a global alias, or a local alias
+// when AccessKeyID and LocalAlias are set.
+type BucketAliasRequest struct {
+	BucketID    string `json:"bucketId"`
+	GlobalAlias string `json:"globalAlias,omitempty"`
+	AccessKeyID string `json:"accessKeyId,omitempty"`
+	LocalAlias  string `json:"localAlias,omitempty"`
+}
+
+func (c *Client) AddBucketAlias(ctx context.Context, req BucketAliasRequest) (*Bucket, error) {
+	bucket := &Bucket{}
+	err := c.do(ctx, http.MethodPost, "/v2/AddBucketAlias", req, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("add bucket alias: %w", err)
+	}
+	return bucket, nil
+}
+
+func (c *Client) RemoveBucketAlias(ctx context.Context, req BucketAliasRequest) (*Bucket, error) {
+	bucket := &Bucket{}
+	err := c.do(ctx, http.MethodPost, "/v2/RemoveBucketAlias", req, bucket)
+	if err != nil {
+		return nil, fmt.Errorf("remove bucket alias: %w", err)
+	}
+	return bucket, nil
+}
@@ -8,9 +8,18 @@ import (
 )
 
 type Bucket struct {
-	ID            string   `json:"id"`
-	GlobalAliases []string `json:"globalAliases"`
-	Keys          []struct {
+	ID            string       `json:"id"`
+	GlobalAliases []string     `json:"globalAliases"`
+	Quotas        BucketQuotas `json:"quotas"`
+	WebsiteAccess bool         `json:"websiteAccess"`
+	WebsiteConfig *struct {
+		IndexDocument string  `json:"indexDocument"`
+		ErrorDocument *string `json:"errorDocument"`
+	} `json:"websiteConfig"`
+	Objects           int64 `json:"objects"`
+	Bytes             int64 `json:"bytes"`
+	UnfinishedUploads int64 `json:"unfinishedUploads"`
+	Keys              []struct {
 		AccessKeyID string `json:"accessKeyId"`
 		Name        string `json:"name"`
 		Permissions struct {
@@ -18,15 +27,54 @@ type Bucket struct {
 			Read  bool `json:"read"`
 			Write bool `json:"write"`
 		} `json:"permissions"`
+		BucketLocalAliases []string `json:"bucketLocalAliases"`
 	} `json:"keys"`
 }
 
+type LocalAlias struct {
+	AccessKeyID string `json:"accessKeyId"`
+	Alias       string `json:"alias"`
+}
+
+// LocalAliases flattens the local aliases each key has for the bucket.
+func (b *Bucket) LocalAliases() []LocalAlias {
+	aliases := []LocalAlias{}
+	for _, key := range b.Keys {
+		for _, alias := range key.BucketLocalAliases {
+			aliases = append(aliases, LocalAlias{
+				AccessKeyID: key.AccessKeyID,
+				Alias:       alias,
+			})
+		}
+	}
+	return aliases
+}
+
+type BucketQuotas struct {
+	MaxSize    *int64 `json:"maxSize"`
+	MaxObjects *int64 `json:"maxObjects"`
+}
+
 func (c *Client) GetBucket(ctx context.Context, id string) (*Bucket, error) {
+	return c.getBucketInfo(ctx, "id", id)
+}
+
+func (c *Client) GetBucketByGlobalAlias(ctx context.Context, alias string) (*Bucket, error) {
+	return c.getBucketInfo(ctx, "globalAlias", alias)
+}
+
+// SearchBucket finds a bucket by a partial id or alias, the search must only
+// match a single bucket.
+func (c *Client) SearchBucket(ctx context.Context, search string) (*Bucket, error) {
+	return c.getBucketInfo(ctx, "search", search)
+}
+
+func (c *Client) getBucketInfo(ctx context.Context, param, value string) (*Bucket, error) {
 	bucket := &Bucket{}
 	err := c.do(
 		ctx,
 		http.MethodGet,
-		fmt.Sprintf("/v2/GetBucketInfo?id=%s", url.QueryEscape(id)),
+		fmt.Sprintf("/v2/GetBucketInfo?%s=%s", param, url.QueryEscape(value)),
 		nil,
 		bucket,
 	)
@@ -36,17 +84,66 @@ func (c *Client) GetBucket(ctx context.Context, id string) (*Bucket, error) {
 	return bucket, nil
 }
 
-func (c *Client) CreateBucket(ctx context.Context, name string) (*Bucket, error) {
+type BucketListItem struct {
+	ID            string       `json:"id"`
+	GlobalAliases []string     `json:"globalAliases"`
+	LocalAliases  []LocalAlias `json:"localAliases"`
+}
+
+func (c *Client) ListBuckets(ctx context.Context) ([]BucketListItem, error) {
+	buckets := []BucketListItem{}
+	err := c.do(ctx, http.MethodGet, "/v2/ListBuckets", nil, &buckets)
+	if err != nil {
+		return nil, fmt.Errorf("list buckets: %w", err)
+	}
+	return buckets, nil
+}
+
+type CreateBucketRequest struct {
+	GlobalAlias string      `json:"globalAlias,omitempty"`
+	LocalAlias  *LocalAlias `json:"localAlias,omitempty"`
+}
+
+func (c *Client) CreateBucket(ctx context.Context, req CreateBucketRequest) (*Bucket, error) {
 	bucket := &Bucket{}
-	err := c.do(ctx, http.MethodPost, "/v2/CreateBucket", map[string]any{
-		"globalAlias": name,
-	}, bucket)
+	err := c.do(ctx, http.MethodPost, "/v2/CreateBucket", req, bucket)
 	if err != nil {
 		return nil, fmt.Errorf("create bucket: %w", err)
 	}
 	return bucket, nil
 }
 
+type UpdateBucketWebsiteAccess struct {
+	Enabled       bool    `json:"enabled"`
+	IndexDocument *string `json:"indexDocument,omitempty"`
+	ErrorDocument *string `json:"errorDocument,omitempty"`
+}
+
+type UpdateBucketRequest struct {
+	// Quotas replaces the bucket's quotas, nil values remove the limit.
+	Quotas        *BucketQuotas              `json:"quotas,omitempty"`
+	WebsiteAccess *UpdateBucketWebsiteAccess `json:"websiteAccess,omitempty"`
+}
+
+func (c *Client) UpdateBucket(
+	ctx context.Context,
+	id string,
+	req UpdateBucketRequest,
+) (*Bucket, error) {
+	bucket := &Bucket{}
+	err := c.do(
+		ctx,
+		http.MethodPost,
+		fmt.Sprintf("/v2/UpdateBucket?id=%s", url.QueryEscape(id)),
+		req,
+		bucket,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("update bucket: %w", err)
+	}
+	return bucket, nil
+}
+
 func (c *Client) DeleteBucket(ctx context.Context, id string) error {
 	err := c.do(ctx, http.MethodPost, fmt.Sprintf("/v2/DeleteBucket?id=%s", id), nil, nil)
 	if err != nil {
@@ -6,24 +6,116 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
+	"net"
 	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type Client struct {
-	url   string
-	token string
+	nodes     *nodes
+	token     string
+	retry     RetryConfig
+	http      *http.Client
+	transport *http.Transport
+	headers   map[string]string
 }
 
-func New(url, token string) *Client {
-	return &Client{
-		url:   url,
-		token: token,
+// RetryConfig controls how requests that fail with a transient error are
+// retried. Only idempotent requests are retried.
+type RetryConfig struct {
+	MaxRetries int
+	WaitMin    time.Duration
+	WaitMax    time.Duration
+}
+
+// DefaultRetryConfig is used when no retry config is passed to New.
+var DefaultRetryConfig = RetryConfig{
+	MaxRetries: 3,
+	WaitMin:    time.Second,
+	WaitMax:    30 * time.Second,
+}
+
+type Option func(*Client)
+
+func WithRetry(retry RetryConfig) Option {
+	return func(c *Client) {
+		c.retry = retry
+	}
+}
+
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(c *Client) {
+		c.transport.TLSClientConfig = cfg
+	}
+}
+
+// WithTimeout limits how long a single attempt of a request can take.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.http.Timeout = timeout
+	}
+}
+
+// WithProxy sends all requests through the proxy, instead of the proxy from
+// the HTTP_PROXY/HTTPS_PROXY environment variables.
+func WithProxy(proxy *url.URL) Option {
+	return func(c *Client) {
+		c.transport.Proxy = http.ProxyURL(proxy)
+	}
+}
+
+// WithHeaders adds extra headers to every request, i.e. for an auth proxy in
+// front of garage. They can't override the headers garage needs.
+func WithHeaders(headers map[string]string) Option {
+	return func(c *Client) {
+		c.headers = headers
+	}
+}
+
+func New(url, token string, opts ...Option) *Client {
+	transport := newTransport()
+	c := &Client{
+		nodes:     newNodes(url),
+		token:     token,
+		retry:     DefaultRetryConfig,
+		http:      &http.Client{Transport: transport},
+		transport: transport,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// newTransport returns a copy of the default transport so options don't leak
+// into other users of it.
+func newTransport() *http.Transport {
+	if transport, ok := http.DefaultTransport.(*http.Transport); ok {
+		return transport.Clone()
 	}
+	return &http.Transport{Proxy: http.ProxyFromEnvironment}
+}
+
+// idempotentPaths are the POST endpoints that are safe to send again if the
+// first attempt may have reached garage.
+var idempotentPaths = []string{
+	"/v2/UpdateBucket",
+	"/v2/UpdateKey",
+	"/v2/AllowBucketKey",
+	"/v2/DenyBucketKey",
 }
 
 func (c *Client) do(
@@ -32,47 +124,207 @@ func (c *Client) do(
 	body any,
 	output any,
 ) error {
-	var reader io.Reader
+	var by []byte
 	if body != nil {
-		by, err := json.Marshal(body)
+		var err error
+		by, err = json.Marshal(body)
 		if err != nil {
 			return fmt.Errorf("marshal request body: %w", err)
 		}
-		reader = bytes.NewBuffer(by)
+	}
+
+	var (
+		out []byte
+		err error
+	)
+	for attempt := 0; ; attempt++ {
+		out, err = c.send(ctx, method, path, by)
+		if attempt >= c.retry.MaxRetries || !c.retryable(ctx, method, path, err) {
+			break
+		}
+
+		wait := c.backoff(attempt)
+		tflog.Debug(c.maskToken(ctx), "retrying request", map[string]any{
+			"path":    path,
+			"attempt": attempt + 1,
+			"wait":    wait.String(),
+			"error":   err.Error(),
+		})
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if len(out) != 0 && output != nil {
+		if err := json.Unmarshal(out, output); err != nil {
+			return fmt.Errorf("unmarhsal body: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// send sends the request to the first node that can be reached.
+func (c *Client) send(
+	ctx context.Context,
+	method, path string,
+	body []byte,
+) ([]byte, error) {
+	var (
+		out []byte
+		err error
+	)
+	for _, node := range c.nodes.order() {
+		out, err = c.sendTo(ctx, node, method, path, body)
+		if ctx.Err() != nil || !canFailover(method, path, err) {
+			apiErr := &APIError{}
+			if err == nil || errors.As(err, &apiErr) {
+				c.nodes.markHealthy(node)
+			}
+			return out, err
+		}
+
+		c.nodes.markUnhealthy(node)
+		tflog.Warn(c.maskToken(ctx), "garage node unreachable", map[string]any{
+			"endpoint": node,
+			"path":     path,
+			"error":    err.Error(),
+		})
+	}
+	return out, err
+}
+
+func (c *Client) sendTo(
+	ctx context.Context,
+	node, method, path string,
+	body []byte,
+) ([]byte, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
 	}
 	req, err := http.NewRequestWithContext(
 		ctx,
 		method,
-		fmt.Sprintf("%s%s", c.url, path),
+		endpoint(node, path),
 		reader,
 	)
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
 	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
 
-	resp, err := http.DefaultClient.Do(req)
+	start := time.Now()
+	resp, err := c.http.Do(req)
 	if err != nil {
-		return fmt.Errorf("do request: %w", err)
+		return nil, fmt.Errorf("do request: %w", err)
 	}
+	defer resp.Body.Close()
 
 	out, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return fmt.Errorf("read response body: %w", err)
-	}
-	if string(out) != "" {
-		tflog.Debug(ctx, "got response body", map[string]any{"body": string(out)})
+		return nil, fmt.Errorf("read response body: %w", err)
 	}
+	c.logRequest(ctx, method, path, resp.StatusCode, time.Since(start), body, out)
 	if resp.StatusCode > 299 {
-		return fmt.Errorf("got status code %d", resp.StatusCode)
+		apiErr := &APIError{}
+		// Not every error has a json body, i.e. from a proxy in front of
+		// garage, so fall back to only the status code
+		_ = json.Unmarshal(out, apiErr)
+		apiErr.StatusCode = resp.StatusCode
+		return nil, apiErr
 	}
 
-	if len(out) != 0 && output != nil {
-		if err := json.Unmarshal(out, output); err != nil {
-			return fmt.Errorf("unmarhsal body: %w", err)
+	return out, nil
+}
+
+// endpoint joins the api path onto the base url, keeping any path prefix the
+// api is served under.
+func endpoint(base, path string) string {
+	return strings.TrimRight(base, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// retryable reports whether the request failed with a transient error and
+// is safe to send again.
+func (c *Client) retryable(ctx context.Context, method, path string, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if method != http.MethodGet {
+		path, _, _ = strings.Cut(path, "?")
+		if !slices.Contains(idempotentPaths, path) {
+			return false
 		}
 	}
 
-	return nil
+	// Connection errors, i.e. the node is restarting
+	if connectionError(err) {
+		return true
+	}
+	apiErr := &APIError{}
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusTooManyRequests ||
+		(apiErr.StatusCode >= 500 && apiErr.StatusCode != http.StatusNotImplemented)
+}
+
+// connectionError reports whether the request failed because the connection
+// to the node was refused, dropped or timed out. Certificate and proxy errors
+// won't go away by sending the request again, so they aren't.
+func connectionError(err error) bool {
+	urlErr := &url.Error{}
+	if !errors.As(err, &urlErr) {
+		return false
+	}
+
+	var (
+		verifyErr    *tls.CertificateVerificationError
+		authorityErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		invalidErr   x509.CertificateInvalidError
+		recordErr    tls.RecordHeaderError
+	)
+	if errors.As(err, &verifyErr) ||
+		errors.As(err, &authorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) ||
+		errors.As(err, &recordErr) {
+		return false
+	}
+
+	opErr := &net.OpError{}
+	if errors.As(err, &opErr) {
+		return opErr.Op != "proxyconnect"
+	}
+	if errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+	return urlErr.Timeout()
+}
+
+// backoff returns the exponential wait for the attempt with jitter, so that
+// several requests don't all hit a recovering node at once.
+func (c *Client) backoff(attempt int) time.Duration {
+	wait := c.retry.WaitMin
+	for i := 0; i < attempt && wait < c.retry.WaitMax; i++ {
+		wait *= 2
+	}
+	wait = min(wait, c.retry.WaitMax)
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + rand.N(wait/2+1)
 }
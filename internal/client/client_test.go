@@ -0,0 +1,273 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItReturnsAPIErrors(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{
+			"code": "NoSuchBucket",
+			"message": "Bucket not found: bongo",
+			"region": "garage",
+			"path": "/v2/GetBucketInfo"
+		}`))
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token").GetBucket(context.Background(), "bongo")
+	require.Error(t, err)
+
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	require.Equal(t, "NoSuchBucket", apiErr.Code)
+	require.Equal(t, "Bucket not found: bongo", apiErr.Message)
+	require.Equal(t, "garage", apiErr.Region)
+	require.Equal(t, "/v2/GetBucketInfo", apiErr.Path)
+	require.True(t, IsNotFound(err))
+	require.False(t, IsUnauthorized(err))
+}
+
+func TestItReturnsAPIErrorsWithoutABody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte("forbidden"))
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token").GetBucket(context.Background(), "bongo")
+	require.Error(t, err)
+
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusForbidden, apiErr.StatusCode)
+	require.Equal(t, "get bucket: got status code 403", err.Error())
+	require.False(t, IsNotFound(err))
+	require.True(t, IsUnauthorized(err))
+}
+
+var testRetry = WithRetry(RetryConfig{
+	MaxRetries: 3,
+	WaitMin:    time.Millisecond,
+	WaitMax:    5 * time.Millisecond,
+})
+
+// hangUp closes the connection without a response, like a node that goes
+// down mid request.
+func hangUp(t *testing.T, w http.ResponseWriter) {
+	hj, ok := w.(http.Hijacker)
+	require.True(t, ok)
+	conn, _, err := hj.Hijack()
+	require.Nil(t, err)
+	_ = conn.Close()
+}
+
+func TestItRetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch calls.Add(1) {
+		case 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case 2:
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			_, _ = w.Write([]byte(`{"id": "bongo"}`))
+		}
+	}))
+	defer srv.Close()
+
+	bucket, err := New(srv.URL, "token", testRetry).GetBucket(context.Background(), "bongo")
+	require.Nil(t, err)
+	require.Equal(t, "bongo", bucket.ID)
+	require.Equal(t, int32(3), calls.Load())
+}
+
+func TestItRetriesConnectionErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			hangUp(t, w)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	defer srv.Close()
+
+	bucket, err := New(srv.URL, "token", testRetry).GetBucket(context.Background(), "bongo")
+	require.Nil(t, err)
+	require.Equal(t, "bongo", bucket.ID)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestItStopsRetryingAfterMaxRetries(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token", testRetry).GetBucket(context.Background(), "bongo")
+	require.Error(t, err)
+	apiErr := &APIError{}
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadGateway, apiErr.StatusCode)
+	require.Equal(t, int32(4), calls.Load())
+}
+
+func TestItRetriesIdempotentPosts(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token", testRetry).
+		UpdateBucket(context.Background(), "bongo", UpdateBucketRequest{})
+	require.Nil(t, err)
+	require.Equal(t, int32(2), calls.Load())
+}
+
+func TestItDoesNotRetryNonIdempotentPosts(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token", testRetry).
+		CreateBucket(context.Background(), CreateBucketRequest{GlobalAlias: "bongo"})
+	require.Error(t, err)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestItDoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token", testRetry).GetBucket(context.Background(), "bongo")
+	require.Error(t, err)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestItKeepsTheEndpointPathPrefix(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.RequestURI()
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	defer srv.Close()
+
+	for _, base := range []string{srv.URL + "/garage-admin", srv.URL + "/garage-admin/"} {
+		_, err := New(base, "token").GetBucket(context.Background(), "bongo")
+		require.Nil(t, err)
+		require.Equal(t, "/garage-admin/v2/GetBucketInfo?id=bongo", got)
+	}
+}
+
+func TestItSendsCustomHeaders(t *testing.T) {
+	var got http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token", WithHeaders(map[string]string{
+		"CF-Access-Client-Id": "bongo",
+		"Authorization":       "Bearer bingo",
+	})).GetBucket(context.Background(), "bongo")
+	require.Nil(t, err)
+	require.Equal(t, "bongo", got.Get("CF-Access-Client-Id"))
+	require.Equal(t, "Bearer token", got.Get("Authorization"))
+}
+
+func TestItTimesOutRequests(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	_, err := New(srv.URL, "token", WithTimeout(10*time.Millisecond), WithRetry(RetryConfig{})).
+		GetBucket(context.Background(), "bongo")
+	require.Error(t, err)
+	require.ErrorContains(t, err, "Client.Timeout exceeded")
+}
+
+func TestItSendsRequestsThroughAProxy(t *testing.T) {
+	var got string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.URL.String()
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	defer proxy.Close()
+	proxyURL, err := url.Parse(proxy.URL)
+	require.Nil(t, err)
+
+	_, err = New("http://garage.internal:3903", "token", WithProxy(proxyURL)).
+		GetBucket(context.Background(), "bongo")
+	require.Nil(t, err)
+	require.Equal(t, "http://garage.internal:3903/v2/GetBucketInfo?id=bongo", got)
+}
+
+func TestItDoesNotRetryCertificateErrors(t *testing.T) {
+	var conns atomic.Int32
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	srv.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	// The server certificate isn't trusted, so the handshake always fails
+	_, err := New(srv.URL, "token", testRetry).GetBucket(context.Background(), "bongo")
+	require.Error(t, err)
+	require.ErrorContains(t, err, "certificate")
+	require.Equal(t, int32(1), conns.Load())
+}
+
+func TestItGetsTheGarageVersion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/GetClusterStatus", r.URL.Path)
+		_, _ = w.Write([]byte(`{
+			"layoutVersion": 1,
+			"nodes": [
+				{"id": "apple", "garageVersion": "v2.0.0", "isUp": false},
+				{"id": "banana", "garageVersion": null, "isUp": true},
+				{"id": "bongo", "garageVersion": "v2.1.0", "isUp": true}
+			]
+		}`))
+	}))
+	defer srv.Close()
+
+	status, err := New(srv.URL, "token").GetClusterStatus(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, "v2.1.0", status.Version())
+}
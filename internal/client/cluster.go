@@ -0,0 +1,37 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type ClusterStatus struct {
+	LayoutVersion int64 `json:"layoutVersion"`
+	Nodes         []struct {
+		ID            string  `json:"id"`
+		GarageVersion *string `json:"garageVersion"`
+		Hostname      *string `json:"hostname"`
+		IsUp          bool    `json:"isUp"`
+	} `json:"nodes"`
+}
+
+// Version returns the garage version of the first node that is up, or an
+// empty string when no node reports one.
+func (s *ClusterStatus) Version() string {
+	for _, node := range s.Nodes {
+		if node.IsUp && node.GarageVersion != nil {
+			return *node.GarageVersion
+		}
+	}
+	return ""
+}
+
+func (c *Client) GetClusterStatus(ctx context.Context) (*ClusterStatus, error) {
+	status := &ClusterStatus{}
+	err := c.do(ctx, http.MethodGet, "/v2/GetClusterStatus", nil, status)
+	if err != nil {
+		return nil, fmt.Errorf("get cluster status: %w", err)
+	}
+	return status, nil
+}
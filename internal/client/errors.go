@@ -0,0 +1,53 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrNotFound matches any APIError for a resource that doesn't exist, use
+// errors.Is(err, ErrNotFound) to check for it.
+var ErrNotFound = errors.New("not found")
+
+// ErrUnauthorized matches any APIError for a request garage rejected the
+// token for.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// APIError is the error body returned by the garage admin api.
+type APIError struct {
+	StatusCode int    `json:"-"`
+	Code       string `json:"code"`
+	Message    string `json:"message"`
+	Region     string `json:"region"`
+	Path       string `json:"path"`
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("got status code %d", e.StatusCode)
+	}
+	return fmt.Sprintf("got status code %d: %s: %s", e.StatusCode, e.Code, e.Message)
+}
+
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	default:
+		return false
+	}
+}
+
+// IsNotFound reports whether the error is from a resource that doesn't exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsUnauthorized reports whether the error is from an invalid token, or one
+// without access to the endpoint.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
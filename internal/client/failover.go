@@ -0,0 +1,84 @@
+package client
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// nodes tracks which garage nodes have failed, any node can serve the admin
+// api so requests move on to the next one when a node can't be reached.
+type nodes struct {
+	mu        sync.Mutex
+	urls      []string
+	unhealthy map[string]bool
+}
+
+func newNodes(urls ...string) *nodes {
+	return &nodes{
+		urls:      urls,
+		unhealthy: map[string]bool{},
+	}
+}
+
+// WithFailover adds more nodes to send requests to when the previous ones
+// can't be reached. They are tried in order after the url passed to New.
+func WithFailover(urls ...string) Option {
+	return func(c *Client) {
+		c.nodes.mu.Lock()
+		defer c.nodes.mu.Unlock()
+		c.nodes.urls = append(c.nodes.urls, urls...)
+	}
+}
+
+// order returns the nodes to try, healthy nodes first in the configured
+// order. Unhealthy nodes are still tried last, so a request can succeed once
+// they recover even when every node has failed before.
+func (n *nodes) order() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	healthy := []string{}
+	unhealthy := []string{}
+	for _, u := range n.urls {
+		if n.unhealthy[u] {
+			unhealthy = append(unhealthy, u)
+			continue
+		}
+		healthy = append(healthy, u)
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (n *nodes) markUnhealthy(u string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.unhealthy[u] = true
+}
+
+func (n *nodes) markHealthy(u string) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	delete(n.unhealthy, u)
+}
+
+// canFailover reports whether the request failed because the node couldn't
+// be reached and is safe to send to another node. Requests that never
+// connected can always be sent again, otherwise only idempotent requests are.
+func canFailover(method, path string, err error) bool {
+	if !connectionError(err) {
+		return false
+	}
+	opErr := &net.OpError{}
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	if method == http.MethodGet {
+		return true
+	}
+	path, _, _ = strings.Cut(path, "?")
+	return slices.Contains(idempotentPaths, path)
+}
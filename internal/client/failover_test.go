@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestItFailsOverToTheNextNode(t *testing.T) {
+	var broken, healthy atomic.Int32
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		broken.Add(1)
+		hangUp(t, w)
+	}))
+	defer bad.Close()
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		healthy.Add(1)
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	defer good.Close()
+
+	c := New(bad.URL, "token", WithFailover(good.URL), WithRetry(RetryConfig{}))
+	for range 3 {
+		bucket, err := c.GetBucket(context.Background(), "bongo")
+		require.Nil(t, err)
+		require.Equal(t, "bongo", bucket.ID)
+	}
+
+	// The broken node is only tried again once the others fail
+	require.Equal(t, int32(1), broken.Load())
+	require.Equal(t, int32(3), healthy.Load())
+}
+
+func TestItFailsOverNonIdempotentRequestsThatNeverConnected(t *testing.T) {
+	down := httptest.NewServer(http.NotFoundHandler())
+	down.Close()
+	var calls atomic.Int32
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	defer good.Close()
+
+	_, err := New(down.URL, "token", WithFailover(good.URL), WithRetry(RetryConfig{})).
+		CreateBucket(context.Background(), CreateBucketRequest{GlobalAlias: "bongo"})
+	require.Nil(t, err)
+	require.Equal(t, int32(1), calls.Load())
+}
+
+func TestItDoesNotFailOverNonIdempotentRequestsThatConnected(t *testing.T) {
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hangUp(t, w)
+	}))
+	defer bad.Close()
+	var calls atomic.Int32
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	defer good.Close()
+
+	_, err := New(bad.URL, "token", WithFailover(good.URL), WithRetry(RetryConfig{})).
+		CreateBucket(context.Background(), CreateBucketRequest{GlobalAlias: "bongo"})
+	require.Error(t, err)
+	require.Equal(t, int32(0), calls.Load())
+}
+
+func TestItDoesNotFailOverAPIErrors(t *testing.T) {
+	bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer bad.Close()
+	var calls atomic.Int32
+	good := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	defer good.Close()
+
+	_, err := New(bad.URL, "token", WithFailover(good.URL)).GetBucket(context.Background(), "bongo")
+	require.True(t, IsNotFound(err))
+	require.Equal(t, int32(0), calls.Load())
+}
+
+func TestNodeOrder(t *testing.T) {
+	n := newNodes("a", "b", "c")
+	require.Equal(t, []string{"a", "b", "c"}, n.order())
+
+	n.markUnhealthy("a")
+	n.markUnhealthy("b")
+	require.Equal(t, []string{"c", "a", "b"}, n.order())
+
+	n.markHealthy("a")
+	require.Equal(t, []string{"a", "c", "b"}, n.order())
+}
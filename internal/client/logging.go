@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// redactedValue replaces sensitive values in logs.
+const redactedValue = "***"
+
+// sensitiveFields are the json keys in request/response bodies whose values
+// are never logged.
+var sensitiveFields = []string{
+	"secretAccessKey",
+	"secretToken",
+}
+
+// logRequest logs a request to the garage api at debug level, with secrets
+// removed from the bodies.
+func (c *Client) logRequest(
+	ctx context.Context,
+	method, path string,
+	status int,
+	latency time.Duration,
+	request, response []byte,
+) {
+	fields := map[string]any{
+		"method":     method,
+		"path":       path,
+		"status":     status,
+		"latency_ms": latency.Milliseconds(),
+	}
+	if len(request) != 0 {
+		fields["request_body"] = redact(request)
+	}
+	if len(response) != 0 {
+		fields["response_body"] = redact(response)
+	}
+	tflog.Debug(c.maskToken(ctx), "garage api request", fields)
+}
+
+// maskToken hides the bearer token anywhere it appears in logs.
+func (c *Client) maskToken(ctx context.Context) context.Context {
+	if c.token == "" {
+		return ctx
+	}
+	return tflog.MaskLogStrings(ctx, c.token)
+}
+
+// redact replaces the values of sensitive fields in a json body, bodies that
+// aren't json are returned as is.
+func redact(body []byte) string {
+	var parsed any
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return string(body)
+	}
+	out, err := json.Marshal(redactValue(parsed))
+	if err != nil {
+		return redactedValue
+	}
+	return string(out)
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if slices.Contains(sensitiveFields, key) {
+				if field != nil {
+					v[key] = redactedValue
+				}
+				continue
+			}
+			v[key] = redactValue(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = redactValue(item)
+		}
+	}
+	return value
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItRedactsSecretsFromLogs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"name": "bongo",
+			"accessKeyId": "GK123",
+			"secretAccessKey": "supersecret"
+		}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &out)
+
+	_, err := New(srv.URL, "admintoken").ImportAccessKey(ctx, ImportKeyRequest{
+		AccessKeyID:     "GK123",
+		SecretAccessKey: "supersecret",
+	})
+	require.NoError(t, err)
+
+	require.NotContains(t, out.String(), "supersecret")
+	require.NotContains(t, out.String(), "admintoken")
+
+	entries, err := tflogtest.MultilineJSONDecode(&out)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, http.MethodPost, entries[0]["method"])
+	require.Equal(t, "/v2/ImportKey", entries[0]["path"])
+	require.EqualValues(t, http.StatusOK, entries[0]["status"])
+	require.Contains(t, entries[0], "latency_ms")
+	require.Contains(t, entries[0]["request_body"], `"secretAccessKey":"***"`)
+	require.Contains(t, entries[0]["response_body"], `"secretAccessKey":"***"`)
+	require.Contains(t, entries[0]["response_body"], `"accessKeyId":"GK123"`)
+}
+
+func TestItMasksTheTokenInLogs(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"code": "Forbidden", "message": "invalid token admintoken"}`))
+	}))
+	defer srv.Close()
+
+	var out bytes.Buffer
+	ctx := tflogtest.RootLogger(t.Context(), &out)
+
+	_, err := New(srv.URL, "admintoken").GetBucket(ctx, "bongo")
+	require.Error(t, err)
+	require.NotContains(t, out.String(), "admintoken")
+}
+
+func TestRedact(t *testing.T) {
+	require.Equal(
+		t,
+		`{"keys":[{"id":"GK1","secretAccessKey":"***"}],"secretAccessKey":null}`,
+		redact([]byte(`{"keys":[{"id":"GK1","secretAccessKey":"abc"}],"secretAccessKey":null}`)),
+	)
+	require.Equal(t, "not json", redact([]byte("not json")))
+}
@@ -2,8 +2,11 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 )
 
 type Permission struct {
@@ -36,7 +39,7 @@ func (c *Client) GetPermissions(
 		}
 	}
 
-	return nil, fmt.Errorf("could not find permission for key/bucket")
+	return nil, fmt.Errorf("could not find permission for key/bucket: %w", ErrNotFound)
 }
 
 type CreatePermissionsBlock struct {
@@ -62,27 +65,170 @@ func (c *Client) CreatePermission(
 	return c.GetPermissions(ctx, req.AccessKeyID, req.BucketID)
 }
 
+// UpdatePermission changes the key's permissions on the bucket to match the
+// request. Only the flags that change are sent, revoking before granting so
+// the key never holds more than it had before or was asked for.
 func (c *Client) UpdatePermission(
 	ctx context.Context,
 	req CreatePermissionRequest,
 ) (*Permission, error) {
-	if _, err := c.CreatePermission(ctx, req); err != nil {
+	current, err := c.GetPermissions(ctx, req.AccessKeyID, req.BucketID)
+	if err != nil && !IsNotFound(err) {
 		return nil, err
 	}
+	if current == nil {
+		// The key isn't in the bucket's list until it has been granted
+		// something
+		current = &Permission{}
+	}
+
+	deny := CreatePermissionsBlock{
+		Owner: current.Owner && !req.Permissions.Owner,
+		Read:  current.Read && !req.Permissions.Read,
+		Write: current.Write && !req.Permissions.Write,
+	}
+	allow := CreatePermissionsBlock{
+		Owner: !current.Owner && req.Permissions.Owner,
+		Read:  !current.Read && req.Permissions.Read,
+		Write: !current.Write && req.Permissions.Write,
+	}
+
+	if !deny.hasAny() && !allow.hasAny() {
+		return &Permission{
+			AccessKeyID: req.AccessKeyID,
+			BucketID:    req.BucketID,
+			Owner:       current.Owner,
+			Read:        current.Read,
+			Write:       current.Write,
+		}, nil
+	}
 
-	inverse := &CreatePermissionRequest{
-		AccessKeyID: req.AccessKeyID,
-		BucketID:    req.BucketID,
+	if deny.hasAny() {
+		err := c.do(ctx, http.MethodPost, "/v2/DenyBucketKey", CreatePermissionRequest{
+			AccessKeyID: req.AccessKeyID,
+			BucketID:    req.BucketID,
+			Permissions: deny,
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("remove bucket permissions: %w", err)
+		}
+	}
+	if allow.hasAny() {
+		err := c.do(ctx, http.MethodPost, "/v2/AllowBucketKey", CreatePermissionRequest{
+			AccessKeyID: req.AccessKeyID,
+			BucketID:    req.BucketID,
+			Permissions: allow,
+		}, nil)
+		if err != nil {
+			err = fmt.Errorf("grant bucket permissions: %w", err)
+			if deny.hasAny() {
+				// Put back what was revoked so the key is left as it was
+				rollback := c.do(ctx, http.MethodPost, "/v2/AllowBucketKey", CreatePermissionRequest{
+					AccessKeyID: req.AccessKeyID,
+					BucketID:    req.BucketID,
+					Permissions: deny,
+				}, nil)
+				if rollback != nil {
+					err = errors.Join(err, fmt.Errorf("restore removed bucket permissions: %w", rollback))
+				}
+			}
+			return nil, err
+		}
+	}
+	return c.GetPermissions(ctx, req.AccessKeyID, req.BucketID)
+}
+
+func (p CreatePermissionsBlock) hasAny() bool {
+	return p.Owner || p.Read || p.Write
+}
+
+// DeletePermission revokes all of the key's permissions on the bucket.
+func (c *Client) DeletePermission(ctx context.Context, keyID, bucketID string) error {
+	err := c.do(ctx, http.MethodPost, "/v2/DenyBucketKey", CreatePermissionRequest{
+		AccessKeyID: keyID,
+		BucketID:    bucketID,
 		Permissions: CreatePermissionsBlock{
-			Owner: !req.Permissions.Owner,
-			Read:  !req.Permissions.Read,
-			Write: !req.Permissions.Write,
+			Owner: true,
+			Read:  true,
+			Write: true,
 		},
+	}, nil)
+	if err != nil {
+		return fmt.Errorf("revoke bucket permissions: %w", err)
 	}
+	return nil
+}
 
-	err := c.do(ctx, http.MethodPost, "/v2/DenyBucketKey", inverse, nil)
+// SetBucketPermissions makes perms, keyed by access key id, the complete set of
+// grants on the bucket, revoking access from any key that isn't in it.
+func (c *Client) SetBucketPermissions(
+	ctx context.Context,
+	bucketID string,
+	perms map[string]CreatePermissionsBlock,
+) (*Bucket, error) {
+	bucket, err := c.GetBucket(ctx, bucketID)
 	if err != nil {
-		return nil, fmt.Errorf("remove bucket permissions: %w", err)
+		return nil, err
 	}
-	return c.GetPermissions(ctx, req.AccessKeyID, req.BucketID)
+
+	for _, key := range bucket.Keys {
+		if _, ok := perms[key.AccessKeyID]; ok {
+			continue
+		}
+		if !CreatePermissionsBlock(key.Permissions).hasAny() {
+			continue
+		}
+		if err := c.DeletePermission(ctx, key.AccessKeyID, bucketID); err != nil {
+			return nil, err
+		}
+	}
+	for _, keyID := range slices.Sorted(maps.Keys(perms)) {
+		_, err := c.UpdatePermission(ctx, CreatePermissionRequest{
+			AccessKeyID: keyID,
+			BucketID:    bucketID,
+			Permissions: perms[keyID],
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.GetBucket(ctx, bucketID)
+}
+
+// SetKeyPermissions makes perms, keyed by bucket id, the complete set of
+// grants the key has, revoking its access to any bucket that isn't in it.
+func (c *Client) SetKeyPermissions(
+	ctx context.Context,
+	keyID string,
+	perms map[string]CreatePermissionsBlock,
+) (*AccessKey, error) {
+	key, err := c.GetAccessKey(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, bucket := range key.Buckets {
+		if _, ok := perms[bucket.ID]; ok {
+			continue
+		}
+		if !CreatePermissionsBlock(bucket.Permissions).hasAny() {
+			continue
+		}
+		if err := c.DeletePermission(ctx, keyID, bucket.ID); err != nil {
+			return nil, err
+		}
+	}
+	for _, bucketID := range slices.Sorted(maps.Keys(perms)) {
+		_, err := c.UpdatePermission(ctx, CreatePermissionRequest{
+			AccessKeyID: keyID,
+			BucketID:    bucketID,
+			Permissions: perms[bucketID],
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.GetAccessKey(ctx, keyID)
 }
@@ -0,0 +1,232 @@
+package client
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type grant struct {
+	key    string
+	bucket string
+}
+
+type permissionCall struct {
+	Path        string
+	AccessKeyID string
+	BucketID    string
+	Permissions CreatePermissionsBlock
+}
+
+// permissionServer fakes the grants between keys and buckets, recording the
+// allow and deny calls. Paths in fail return a 500.
+func permissionServer(
+	t *testing.T,
+	perms map[grant]CreatePermissionsBlock,
+	fail ...string,
+) (*httptest.Server, func() []permissionCall) {
+	var (
+		mu    sync.Mutex
+		calls []permissionCall
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		id := r.URL.Query().Get("id")
+		switch r.URL.Path {
+		case "/v2/GetBucketInfo":
+			keys := []map[string]any{}
+			for _, g := range sortedGrants(perms) {
+				if g.bucket == id {
+					keys = append(keys, map[string]any{"accessKeyId": g.key, "permissions": perms[g]})
+				}
+			}
+			body, err := json.Marshal(map[string]any{"id": id, "keys": keys})
+			require.Nil(t, err)
+			_, _ = w.Write(body)
+			return
+		case "/v2/GetKeyInfo":
+			buckets := []map[string]any{}
+			for _, g := range sortedGrants(perms) {
+				if g.key == id {
+					buckets = append(buckets, map[string]any{"id": g.bucket, "permissions": perms[g]})
+				}
+			}
+			body, err := json.Marshal(map[string]any{"accessKeyId": id, "buckets": buckets})
+			require.Nil(t, err)
+			_, _ = w.Write(body)
+			return
+		}
+
+		by, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+		req := CreatePermissionRequest{}
+		require.Nil(t, json.Unmarshal(by, &req))
+		calls = append(calls, permissionCall{
+			Path:        r.URL.Path,
+			AccessKeyID: req.AccessKeyID,
+			BucketID:    req.BucketID,
+			Permissions: req.Permissions,
+		})
+		for _, path := range fail {
+			if path == r.URL.Path {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+		}
+
+		g := grant{key: req.AccessKeyID, bucket: req.BucketID}
+		current := perms[g]
+		switch r.URL.Path {
+		case "/v2/AllowBucketKey":
+			current.Owner = current.Owner || req.Permissions.Owner
+			current.Read = current.Read || req.Permissions.Read
+			current.Write = current.Write || req.Permissions.Write
+		case "/v2/DenyBucketKey":
+			current.Owner = current.Owner && !req.Permissions.Owner
+			current.Read = current.Read && !req.Permissions.Read
+			current.Write = current.Write && !req.Permissions.Write
+		}
+		perms[g] = current
+	}))
+	return srv, func() []permissionCall {
+		mu.Lock()
+		defer mu.Unlock()
+		return calls
+	}
+}
+
+func sortedGrants(perms map[grant]CreatePermissionsBlock) []grant {
+	return slices.SortedFunc(maps.Keys(perms), func(a, b grant) int {
+		return cmp.Or(cmp.Compare(a.key, b.key), cmp.Compare(a.bucket, b.bucket))
+	})
+}
+
+func TestItOnlySendsChangedPermissions(t *testing.T) {
+	srv, calls := permissionServer(t, map[grant]CreatePermissionsBlock{
+		{"key", "bucket"}: {Owner: true, Read: true},
+	})
+	defer srv.Close()
+
+	perms, err := New(srv.URL, "token").UpdatePermission(t.Context(), CreatePermissionRequest{
+		AccessKeyID: "key",
+		BucketID:    "bucket",
+		Permissions: CreatePermissionsBlock{Owner: true, Write: true},
+	})
+	require.Nil(t, err)
+	require.Equal(t, &Permission{
+		AccessKeyID: "key",
+		BucketID:    "bucket",
+		Owner:       true,
+		Write:       true,
+	}, perms)
+	require.Equal(t, []permissionCall{
+		{"/v2/DenyBucketKey", "key", "bucket", CreatePermissionsBlock{Read: true}},
+		{"/v2/AllowBucketKey", "key", "bucket", CreatePermissionsBlock{Write: true}},
+	}, calls())
+}
+
+func TestItDoesNotSendUnchangedPermissions(t *testing.T) {
+	srv, calls := permissionServer(t, map[grant]CreatePermissionsBlock{
+		{"key", "bucket"}: {Read: true},
+	})
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token").UpdatePermission(t.Context(), CreatePermissionRequest{
+		AccessKeyID: "key",
+		BucketID:    "bucket",
+		Permissions: CreatePermissionsBlock{Read: true},
+	})
+	require.Nil(t, err)
+	require.Empty(t, calls())
+}
+
+func TestItRestoresPermissionsWhenAGrantFails(t *testing.T) {
+	srv, calls := permissionServer(t, map[grant]CreatePermissionsBlock{
+		{"key", "bucket"}: {Read: true},
+	}, "/v2/AllowBucketKey")
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token", WithRetry(RetryConfig{})).
+		UpdatePermission(t.Context(), CreatePermissionRequest{
+			AccessKeyID: "key",
+			BucketID:    "bucket",
+			Permissions: CreatePermissionsBlock{Write: true},
+		})
+	require.Error(t, err)
+	require.ErrorContains(t, err, "grant bucket permissions")
+	require.Equal(t, []permissionCall{
+		{"/v2/DenyBucketKey", "key", "bucket", CreatePermissionsBlock{Read: true}},
+		{"/v2/AllowBucketKey", "key", "bucket", CreatePermissionsBlock{Write: true}},
+		{"/v2/AllowBucketKey", "key", "bucket", CreatePermissionsBlock{Read: true}},
+	}, calls())
+}
+
+func TestItDoesNotGrantWhenARevokeFails(t *testing.T) {
+	srv, calls := permissionServer(t, map[grant]CreatePermissionsBlock{
+		{"key", "bucket"}: {Read: true},
+	}, "/v2/DenyBucketKey")
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token", WithRetry(RetryConfig{})).
+		UpdatePermission(t.Context(), CreatePermissionRequest{
+			AccessKeyID: "key",
+			BucketID:    "bucket",
+			Permissions: CreatePermissionsBlock{Write: true},
+		})
+	require.Error(t, err)
+	require.Equal(t, []permissionCall{
+		{"/v2/DenyBucketKey", "key", "bucket", CreatePermissionsBlock{Read: true}},
+	}, calls())
+}
+
+func TestItSetsTheCompleteBucketPermissions(t *testing.T) {
+	srv, calls := permissionServer(t, map[grant]CreatePermissionsBlock{
+		{"kept", "bucket"}:    {Read: true},
+		{"stray", "bucket"}:   {Read: true, Write: true},
+		{"revoked", "bucket"}: {},
+		{"stray", "other"}:    {Read: true},
+	})
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token").SetBucketPermissions(t.Context(), "bucket", map[string]CreatePermissionsBlock{
+		"kept":  {Read: true, Write: true},
+		"added": {Owner: true},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []permissionCall{
+		{"/v2/DenyBucketKey", "stray", "bucket", CreatePermissionsBlock{Owner: true, Read: true, Write: true}},
+		{"/v2/AllowBucketKey", "added", "bucket", CreatePermissionsBlock{Owner: true}},
+		{"/v2/AllowBucketKey", "kept", "bucket", CreatePermissionsBlock{Write: true}},
+	}, calls())
+}
+
+func TestItSetsTheCompleteKeyPermissions(t *testing.T) {
+	srv, calls := permissionServer(t, map[grant]CreatePermissionsBlock{
+		{"key", "kept"}:    {Read: true},
+		{"key", "stray"}:   {Write: true},
+		{"key", "revoked"}: {},
+		{"other", "stray"}: {Read: true},
+	})
+	defer srv.Close()
+
+	_, err := New(srv.URL, "token").SetKeyPermissions(t.Context(), "key", map[string]CreatePermissionsBlock{
+		"kept":  {Read: true, Write: true},
+		"added": {Read: true},
+	})
+	require.Nil(t, err)
+	require.Equal(t, []permissionCall{
+		{"/v2/DenyBucketKey", "key", "stray", CreatePermissionsBlock{Owner: true, Read: true, Write: true}},
+		{"/v2/AllowBucketKey", "key", "added", CreatePermissionsBlock{Read: true}},
+		{"/v2/AllowBucketKey", "key", "kept", CreatePermissionsBlock{Write: true}},
+	}, calls())
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// TLSConfig describes how to verify the admin api and authenticate with it
+// when it is served over https.
+type TLSConfig struct {
+	// CACertFile is the path to a PEM encoded CA bundle.
+	CACertFile string
+	// CACertPEM is a PEM encoded CA bundle.
+	CACertPEM string
+	// ClientCertPEM and ClientKeyPEM are the PEM encoded keypair used for
+	// mutual TLS.
+	ClientCertPEM string
+	ClientKeyPEM  string
+	// ServerName overrides the name used to verify the server certificate.
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// Build creates the tls config, CAs from both the file and PEM are trusted
+// alongside the system pool.
+func (t TLSConfig) Build() (*tls.Config, error) {
+	cfg := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         t.ServerName,
+		InsecureSkipVerify: t.InsecureSkipVerify,
+	}
+
+	if t.CACertFile != "" || t.CACertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if t.CACertFile != "" {
+			by, err := os.ReadFile(t.CACertFile)
+			if err != nil {
+				return nil, fmt.Errorf("read ca cert file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(by) {
+				return nil, fmt.Errorf("no certificates found in %s", t.CACertFile)
+			}
+		}
+		if t.CACertPEM != "" && !pool.AppendCertsFromPEM([]byte(t.CACertPEM)) {
+			return nil, errors.New("no certificates found in ca cert pem")
+		}
+		cfg.RootCAs = pool
+	}
+
+	if (t.ClientCertPEM == "") != (t.ClientKeyPEM == "") {
+		return nil, errors.New("client cert and client key must be set together")
+	}
+	if t.ClientCertPEM != "" {
+		cert, err := tls.X509KeyPair([]byte(t.ClientCertPEM), []byte(t.ClientKeyPEM))
+		if err != nil {
+			return nil, fmt.Errorf("load client keypair: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func tlsServer(t *testing.T, clientAuth tls.ClientAuthType) *httptest.Server {
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id": "bongo"}`))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: clientAuth}
+	srv.StartTLS()
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func serverCAPEM(srv *httptest.Server) string {
+	return string(pem.EncodeToMemory(&pem.Block{
+		Type:  "CERTIFICATE",
+		Bytes: srv.Certificate().Raw,
+	}))
+}
+
+func clientKeypairPEM(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Minute),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.Nil(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.Nil(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+}
+
+func tlsClient(t *testing.T, srv *httptest.Server, cfg TLSConfig) *Client {
+	tlsCfg, err := cfg.Build()
+	require.Nil(t, err)
+	return New(srv.URL, "token", WithTLSConfig(tlsCfg), WithRetry(RetryConfig{}))
+}
+
+func TestItVerifiesTheServerWithACAPEM(t *testing.T) {
+	srv := tlsServer(t, tls.NoClientCert)
+
+	_, err := tlsClient(t, srv, TLSConfig{}).GetBucket(context.Background(), "bongo")
+	require.Error(t, err)
+
+	_, err = tlsClient(t, srv, TLSConfig{CACertPEM: serverCAPEM(srv)}).
+		GetBucket(context.Background(), "bongo")
+	require.Nil(t, err)
+}
+
+func TestItVerifiesTheServerWithACAFile(t *testing.T) {
+	srv := tlsServer(t, tls.NoClientCert)
+	path := filepath.Join(t.TempDir(), "ca.pem")
+	require.Nil(t, os.WriteFile(path, []byte(serverCAPEM(srv)), 0600))
+
+	_, err := tlsClient(t, srv, TLSConfig{CACertFile: path}).
+		GetBucket(context.Background(), "bongo")
+	require.Nil(t, err)
+}
+
+func TestItSkipsVerification(t *testing.T) {
+	srv := tlsServer(t, tls.NoClientCert)
+
+	_, err := tlsClient(t, srv, TLSConfig{InsecureSkipVerify: true}).
+		GetBucket(context.Background(), "bongo")
+	require.Nil(t, err)
+}
+
+func TestItSendsAClientCertificate(t *testing.T) {
+	srv := tlsServer(t, tls.RequireAnyClientCert)
+	cert, key := clientKeypairPEM(t)
+
+	_, err := tlsClient(t, srv, TLSConfig{CACertPEM: serverCAPEM(srv)}).
+		GetBucket(context.Background(), "bongo")
+	require.Error(t, err)
+
+	_, err = tlsClient(t, srv, TLSConfig{
+		CACertPEM:     serverCAPEM(srv),
+		ClientCertPEM: cert,
+		ClientKeyPEM:  key,
+	}).GetBucket(context.Background(), "bongo")
+	require.Nil(t, err)
+}
+
+func TestItRejectsInvalidTLSConfig(t *testing.T) {
+	_, err := TLSConfig{CACertPEM: "bongo"}.Build()
+	require.Error(t, err)
+
+	cert, _ := clientKeypairPEM(t)
+	_, err = TLSConfig{ClientCertPEM: cert}.Build()
+	require.Error(t, err)
+}
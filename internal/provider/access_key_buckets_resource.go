@@ -0,0 +1,237 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AccessKeyBucketsResource{}
+var _ resource.ResourceWithImportState = &AccessKeyBucketsResource{}
+
+func NewAccessKeyBucketsResource() resource.Resource {
+	return &AccessKeyBucketsResource{}
+}
+
+// AccessKeyBucketsResource defines the resource implementation.
+type AccessKeyBucketsResource struct {
+	client *client.Client
+}
+
+// AccessKeyBucketsResourceModel describes the resource data model.
+type AccessKeyBucketsResourceModel struct {
+	ID          types.String                `tfsdk:"id"`
+	AccessKeyID types.String                `tfsdk:"access_key_id"`
+	Buckets     map[string]PermissionsModel `tfsdk:"buckets"`
+}
+
+func (r *AccessKeyBucketsResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_access_key_buckets"
+}
+
+func (r *AccessKeyBucketsResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages every bucket an access key has access to, access is revoked from any bucket that isn't listed. " +
+			"Don't use it together with `garage_permission` or `garage_bucket_permissions` for the same key",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The access key id",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"access_key_id": schema.StringAttribute{
+				MarkdownDescription: "The access key id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"buckets": schema.MapNestedAttribute{
+				MarkdownDescription: "The permissions the key has on each bucket, keyed by bucket id",
+				Required:            true,
+				NestedObject:        permissionsNestedObject(),
+			},
+		},
+	}
+}
+
+func (r *AccessKeyBucketsResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	setup, ok := req.ProviderData.(setupData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected setupData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = setup.client
+}
+
+func (r *AccessKeyBucketsResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data AccessKeyBucketsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.client.SetKeyPermissions(
+		ctx,
+		data.AccessKeyID.ValueString(),
+		permissionsRequest(data.Buckets),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("could not set key permissions", err.Error())
+		return
+	}
+
+	mapAccessKeyBucketsToData(&data, key)
+
+	tflog.Trace(ctx, "created access key buckets")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessKeyBucketsResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data AccessKeyBucketsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.client.GetAccessKey(ctx, data.ID.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("could not get key", err.Error())
+		return
+	}
+
+	mapAccessKeyBucketsToData(&data, key)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessKeyBucketsResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data AccessKeyBucketsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, err := r.client.SetKeyPermissions(
+		ctx,
+		data.AccessKeyID.ValueString(),
+		permissionsRequest(data.Buckets),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("could not set key permissions", err.Error())
+		return
+	}
+
+	mapAccessKeyBucketsToData(&data, key)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AccessKeyBucketsResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var data AccessKeyBucketsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for bucketID := range data.Buckets {
+		err := r.client.DeletePermission(ctx, data.AccessKeyID.ValueString(), bucketID)
+		if client.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("could not delete permissions", err.Error())
+			return
+		}
+	}
+}
+
+func (r *AccessKeyBucketsResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// mapAccessKeyBucketsToData maps the buckets the key has access to, see
+// mapGrants.
+func mapAccessKeyBucketsToData(data *AccessKeyBucketsResourceModel, key *client.AccessKey) {
+	grants := map[string]client.CreatePermissionsBlock{}
+	for _, bucket := range key.Buckets {
+		grants[bucket.ID] = client.CreatePermissionsBlock(bucket.Permissions)
+	}
+
+	data.ID = types.StringValue(key.AccessKeyID)
+	data.AccessKeyID = types.StringValue(key.AccessKeyID)
+	data.Buckets = mapGrants(data.Buckets, grants)
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+func TestAccAccessKeyBucketsResource(t *testing.T) {
+	garage, c, cancel := garageWithClient(t)
+	defer cancel()
+
+	var keyID, dataID, strayID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: garage + testAccAccessKeyBucketsResourceConfig(true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_access_key_buckets.test",
+						tfjsonpath.New("buckets"),
+						knownvalue.MapSizeExact(2),
+					),
+				},
+				Check: func(s *terraform.State) error {
+					keyID = s.RootModule().Resources["garage_access_key.test"].Primary.ID
+					dataID = s.RootModule().Resources["garage_bucket.data"].Primary.ID
+					strayID = s.RootModule().Resources["garage_bucket.stray"].Primary.ID
+					return nil
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "garage_access_key_buckets.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Grants made outside of terraform are revoked
+			{
+				PreConfig: func() {
+					_, err := c.CreatePermission(t.Context(), client.CreatePermissionRequest{
+						AccessKeyID: keyID,
+						BucketID:    strayID,
+						Permissions: client.CreatePermissionsBlock{Write: true},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: garage + testAccAccessKeyBucketsResourceConfig(true),
+				Check: func(s *terraform.State) error {
+					return testAccCheckNoAccess(t, c, keyID, strayID)
+				},
+			},
+			// Buckets deleted outside of terraform are granted again once
+			// they are recreated
+			{
+				PreConfig: func() {
+					if err := c.DeleteBucket(t.Context(), dataID); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: garage + testAccAccessKeyBucketsResourceConfig(true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_access_key_buckets.test",
+						tfjsonpath.New("buckets"),
+						knownvalue.MapSizeExact(2),
+					),
+				},
+				Check: func(s *terraform.State) error {
+					data := s.RootModule().Resources["garage_bucket.data"].Primary.ID
+					if data == dataID {
+						return fmt.Errorf("expected bucket %s to be recreated", dataID)
+					}
+					attrs := s.RootModule().Resources["garage_access_key_buckets.test"].Primary.Attributes
+					if attrs["buckets."+data+".read"] != "true" || attrs["buckets."+data+".write"] != "true" {
+						return fmt.Errorf("expected read and write on the recreated bucket, got %v", attrs)
+					}
+					return nil
+				},
+			},
+			// Update and Read testing
+			{
+				Config: garage + testAccAccessKeyBucketsResourceConfig(false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_access_key_buckets.test",
+						tfjsonpath.New("buckets"),
+						knownvalue.MapSizeExact(1),
+					),
+				},
+				Check: func(s *terraform.State) error {
+					logs := s.RootModule().Resources["garage_bucket.logs"].Primary.ID
+					return testAccCheckNoAccess(t, c, keyID, logs)
+				},
+			},
+		},
+	})
+}
+
+func testAccAccessKeyBucketsResourceConfig(logs bool) string {
+	logsBucket := ""
+	if logs {
+		logsBucket = `
+		(garage_bucket.logs.id) = {
+			write = true
+		}`
+	}
+	return fmt.Sprintf(`
+resource "garage_access_key" "test" {
+	name = "bongo"
+}
+resource "garage_bucket" "data" {
+	name = "data"
+}
+resource "garage_bucket" "logs" {
+	name = "logs"
+}
+resource "garage_bucket" "stray" {
+	name = "stray"
+}
+resource "garage_access_key_buckets" "test" {
+	access_key_id = garage_access_key.test.id
+	buckets = {
+		(garage_bucket.data.id) = {
+			read = true
+			write = true
+		}%s
+	}
+}
+`, logsBucket)
+}
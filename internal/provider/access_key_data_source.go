@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AccessKeyDataSource{}
+
+func NewAccessKeyDataSource() datasource.DataSource {
+	return &AccessKeyDataSource{}
+}
+
+// AccessKeyDataSource defines the data source implementation.
+type AccessKeyDataSource struct {
+	client *client.Client
+}
+
+// AccessKeyDataSourceModel describes the data source data model.
+type AccessKeyDataSourceModel struct {
+	ID                types.String           `tfsdk:"id"`
+	Search            types.String           `tfsdk:"search"`
+	Name              types.String           `tfsdk:"name"`
+	Expiration        types.String           `tfsdk:"expiration"`
+	Created           types.String           `tfsdk:"created"`
+	AllowCreateBucket types.Bool             `tfsdk:"allow_create_bucket"`
+	Buckets           []AccessKeyBucketModel `tfsdk:"buckets"`
+}
+
+// AccessKeyBucketModel describes the permissions a key has on a bucket.
+type AccessKeyBucketModel struct {
+	ID            types.String   `tfsdk:"id"`
+	GlobalAliases []types.String `tfsdk:"global_aliases"`
+	LocalAliases  []types.String `tfsdk:"local_aliases"`
+	Owner         types.Bool     `tfsdk:"owner"`
+	Read          types.Bool     `tfsdk:"read"`
+	Write         types.Bool     `tfsdk:"write"`
+}
+
+func (d *AccessKeyDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_access_key"
+}
+
+func (d *AccessKeyDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Access key data source, looked up by exactly one of `id` or `search`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The access key id",
+				Optional:            true,
+				Computed:            true,
+			},
+			"search": schema.StringAttribute{
+				MarkdownDescription: "A partial id or name that matches a single key",
+				Optional:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the access key",
+				Computed:            true,
+			},
+			"expiration": schema.StringAttribute{
+				MarkdownDescription: "The time in RFC 3339 format that the key expires, null when it never expires",
+				Computed:            true,
+			},
+			"created": schema.StringAttribute{
+				MarkdownDescription: "The time in RFC 3339 format that the key was created",
+				Computed:            true,
+			},
+			"allow_create_bucket": schema.BoolAttribute{
+				MarkdownDescription: "Whether the key can create buckets",
+				Computed:            true,
+			},
+			"buckets": schema.ListNestedAttribute{
+				MarkdownDescription: "The buckets the key has access to",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the bucket",
+							Computed:            true,
+						},
+						"global_aliases": schema.ListAttribute{
+							MarkdownDescription: "The global aliases of the bucket",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"local_aliases": schema.ListAttribute{
+							MarkdownDescription: "The local aliases the key has for the bucket",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"owner": schema.BoolAttribute{
+							MarkdownDescription: "Whether the key is the owner of the bucket",
+							Computed:            true,
+						},
+						"read": schema.BoolAttribute{
+							MarkdownDescription: "Whether the key can read from the bucket",
+							Computed:            true,
+						},
+						"write": schema.BoolAttribute{
+							MarkdownDescription: "Whether the key can write to the bucket",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AccessKeyDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	setup, ok := req.ProviderData.(setupData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected setupData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = setup.client
+}
+
+func (d *AccessKeyDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data AccessKeyDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		key *client.AccessKey
+		err error
+	)
+	switch {
+	case !data.ID.IsNull() && data.Search.IsNull():
+		key, err = d.client.GetAccessKey(ctx, data.ID.ValueString())
+	case data.ID.IsNull() && !data.Search.IsNull():
+		key, err = d.client.SearchAccessKey(ctx, data.Search.ValueString())
+	default:
+		resp.Diagnostics.AddError(
+			"invalid input",
+			"exactly one of id or search must be set",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("could not get key", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(key.AccessKeyID)
+	data.Name = types.StringValue(key.Name)
+	data.Expiration = types.StringPointerValue(key.Expiration)
+	data.Created = types.StringPointerValue(key.Created)
+	data.AllowCreateBucket = types.BoolValue(key.Permissions.CreateBucket)
+	data.Buckets = []AccessKeyBucketModel{}
+	for _, bucket := range key.Buckets {
+		item := AccessKeyBucketModel{
+			ID:            types.StringValue(bucket.ID),
+			GlobalAliases: []types.String{},
+			LocalAliases:  []types.String{},
+			Owner:         types.BoolValue(bucket.Permissions.Owner),
+			Read:          types.BoolValue(bucket.Permissions.Read),
+			Write:         types.BoolValue(bucket.Permissions.Write),
+		}
+		for _, alias := range bucket.GlobalAliases {
+			item.GlobalAliases = append(item.GlobalAliases, types.StringValue(alias))
+		}
+		for _, alias := range bucket.LocalAliases {
+			item.LocalAliases = append(item.LocalAliases, types.StringValue(alias))
+		}
+		data.Buckets = append(data.Buckets, item)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccAccessKeyDataSource(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: garage + testAccAccessKeyDataSourceConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.garage_access_key.by_id",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("bongo"),
+					),
+					statecheck.ExpectKnownValue(
+						"data.garage_access_key.by_id",
+						tfjsonpath.New("buckets"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"global_aliases": knownvalue.ListExact([]knownvalue.Check{
+									knownvalue.StringExact("apple"),
+								}),
+								"owner": knownvalue.Bool(false),
+								"read":  knownvalue.Bool(true),
+								"write": knownvalue.Bool(false),
+							}),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.garage_access_key.by_search",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("bongo"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccAccessKeyDataSourceConfig() string {
+	return `
+resource "garage_access_key" "test" {
+	name = "bongo"
+	never_expires = true
+}
+resource "garage_bucket" "test" {
+	name = "apple"
+}
+resource "garage_permission" "test" {
+	access_key_id = garage_access_key.test.id
+	bucket_id = garage_bucket.test.id
+	read = true
+}
+data "garage_access_key" "by_id" {
+	id = garage_permission.test.access_key_id
+}
+data "garage_access_key" "by_search" {
+	search = "bongo"
+	depends_on = [garage_access_key.test]
+}
+`
+}
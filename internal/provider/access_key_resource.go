@@ -7,6 +7,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
@@ -33,6 +35,11 @@ type AccessKeyResourceModel struct {
 	SecretAccessKey types.String `tfsdk:"secret_access_key"`
 	Expiration      types.String `tfsdk:"expiration"`
 	NeverExpires    types.Bool   `tfsdk:"never_expires"`
+
+	AllowCreateBucket types.Bool `tfsdk:"allow_create_bucket"`
+
+	ImportAccessKeyID     types.String `tfsdk:"import_access_key_id"`
+	ImportSecretAccessKey types.String `tfsdk:"import_secret_access_key"`
 }
 
 func (r *AccessKeyResource) Metadata(
@@ -80,6 +87,26 @@ func (r *AccessKeyResource) Schema(
 				Optional:            true,
 				Computed:            true,
 			},
+			"allow_create_bucket": schema.BoolAttribute{
+				MarkdownDescription: "Whether the key can create buckets",
+				Optional:            true,
+				Computed:            true,
+			},
+			"import_access_key_id": schema.StringAttribute{
+				MarkdownDescription: "An existing access key id to import instead of generating one, requires `import_secret_access_key`",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"import_secret_access_key": schema.StringAttribute{
+				MarkdownDescription: "The secret access key of the imported key, requires `import_access_key_id`",
+				Optional:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
 		},
 	}
 }
@@ -130,11 +157,28 @@ func (r *AccessKeyResource) Create(
 		return
 	}
 
-	key, err := r.client.CreateAccessKey(ctx, client.CreateKeyRequest{
-		Name:         data.Name.ValueString(),
-		Expiration:   data.Expiration.ValueString(),
-		NeverExpires: data.NeverExpires.ValueBool(),
-	})
+	if data.ImportAccessKeyID.IsNull() != data.ImportSecretAccessKey.IsNull() {
+		resp.Diagnostics.AddError(
+			"invalid input",
+			"import_access_key_id and import_secret_access_key must be set together",
+		)
+		return
+	}
+
+	var (
+		key *client.AccessKey
+		err error
+	)
+	if data.ImportAccessKeyID.IsNull() {
+		key, err = r.client.CreateAccessKey(ctx, client.CreateKeyRequest{
+			Name:         data.Name.ValueString(),
+			Expiration:   data.Expiration.ValueString(),
+			NeverExpires: data.NeverExpires.ValueBool(),
+			Allow:        keyPermissionsAllow(data.AllowCreateBucket),
+		})
+	} else {
+		key, err = r.importKey(ctx, &data)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("could not create key", err.Error())
 		return
@@ -162,6 +206,10 @@ func (r *AccessKeyResource) Read(
 	}
 
 	key, err := r.client.GetAccessKey(ctx, data.ID.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("could not get key", err.Error())
 		return
@@ -192,8 +240,11 @@ func (r *AccessKeyResource) Update(
 		ctx,
 		data.ID.ValueString(),
 		client.CreateKeyRequest{
+			Name:         data.Name.ValueString(),
 			Expiration:   data.Expiration.ValueString(),
 			NeverExpires: data.NeverExpires.ValueBool(),
+			Allow:        keyPermissionsAllow(data.AllowCreateBucket),
+			Deny:         keyPermissionsDeny(data.AllowCreateBucket),
 		},
 	)
 	if err != nil {
@@ -208,10 +259,65 @@ func (r *AccessKeyResource) Update(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// importKey creates the key from existing credentials, the import endpoint
+// only takes a name so the expiration is set afterwards. The key is deleted
+// again when that fails.
+func (r *AccessKeyResource) importKey(
+	ctx context.Context,
+	data *AccessKeyResourceModel,
+) (*client.AccessKey, error) {
+	key, err := r.client.ImportAccessKey(ctx, client.ImportKeyRequest{
+		AccessKeyID:     data.ImportAccessKeyID.ValueString(),
+		SecretAccessKey: data.ImportSecretAccessKey.ValueString(),
+		Name:            data.Name.ValueString(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if data.Expiration.ValueString() != "" || data.AllowCreateBucket.ValueBool() {
+		updated, err := r.client.UpdateAccessKey(ctx, key.AccessKeyID, client.CreateKeyRequest{
+			Name:       data.Name.ValueString(),
+			Expiration: data.Expiration.ValueString(),
+			Allow:      keyPermissionsAllow(data.AllowCreateBucket),
+		})
+		if err != nil {
+			// Remove the imported key, it isn't saved to state so it would
+			// otherwise block importing it again
+			if rerr := r.client.DeleteAccessKey(ctx, key.AccessKeyID); rerr != nil {
+				return nil, fmt.Errorf("%w, rollback failed: %w", err, rerr)
+			}
+			return nil, err
+		}
+		key = updated
+	}
+	key.SecretAccessKey = data.ImportSecretAccessKey.ValueStringPointer()
+	return key, nil
+}
+
+// keyPermissionsAllow returns the permissions to grant, leaving them untouched
+// when not configured.
+func keyPermissionsAllow(createBucket types.Bool) *client.KeyPermissions {
+	if !createBucket.ValueBool() {
+		return nil
+	}
+	return &client.KeyPermissions{CreateBucket: true}
+}
+
+// keyPermissionsDeny returns the permissions to revoke, leaving them untouched
+// when not configured.
+func keyPermissionsDeny(createBucket types.Bool) *client.KeyPermissions {
+	if createBucket.IsNull() || createBucket.IsUnknown() || createBucket.ValueBool() {
+		return nil
+	}
+	return &client.KeyPermissions{CreateBucket: true}
+}
+
 func mapKeyToData(data *AccessKeyResourceModel, key *client.AccessKey) {
 	data.ID = types.StringValue(key.AccessKeyID)
 	data.Name = types.StringValue(key.Name)
 	data.AccessKeyID = types.StringValue(key.AccessKeyID)
+	data.AllowCreateBucket = types.BoolValue(key.Permissions.CreateBucket)
 	data.Expiration = types.StringNull()
 	if key.Expiration == nil {
 		data.NeverExpires = types.BoolValue(true)
@@ -1,8 +1,10 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
@@ -13,6 +15,8 @@ func TestAccAccessKeyResource(t *testing.T) {
 	garage, cancel := garage(t)
 	defer cancel()
 
+	sameID := statecheck.CompareValue(compare.ValuesSame())
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -21,6 +25,7 @@ func TestAccAccessKeyResource(t *testing.T) {
 			{
 				Config: garage + testAccAccessKeyResourceNeverExpiresConfig(),
 				ConfigStateChecks: []statecheck.StateCheck{
+					sameID.AddStateValue("garage_access_key.test", tfjsonpath.New("id")),
 					statecheck.ExpectKnownValue(
 						"garage_access_key.test",
 						tfjsonpath.New("name"),
@@ -54,10 +59,63 @@ func TestAccAccessKeyResource(t *testing.T) {
 					),
 				},
 			},
+			// Allow create bucket testing
+			{
+				Config: garage + testAccAccessKeyResourceAllowCreateBucketConfig(true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_access_key.test",
+						tfjsonpath.New("allow_create_bucket"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Deny create bucket testing
+			{
+				Config: garage + testAccAccessKeyResourceAllowCreateBucketConfig(false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_access_key.test",
+						tfjsonpath.New("allow_create_bucket"),
+						knownvalue.Bool(false),
+					),
+				},
+			},
+			// Rename testing, the key is kept
+			{
+				Config: garage + testAccAccessKeyResourceRenamedConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					sameID.AddStateValue("garage_access_key.test", tfjsonpath.New("id")),
+					statecheck.ExpectKnownValue(
+						"garage_access_key.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("bingo"),
+					),
+				},
+			},
 		},
 	})
 }
 
+func testAccAccessKeyResourceAllowCreateBucketConfig(allow bool) string {
+	return fmt.Sprintf(`
+resource "garage_access_key" "test" {
+	name = "bongo"
+	never_expires = true
+	allow_create_bucket = %t
+}
+`, allow)
+}
+
+func testAccAccessKeyResourceRenamedConfig() string {
+	return `
+resource "garage_access_key" "test" {
+	name = "bingo"
+	never_expires = true
+}
+`
+}
+
 func testAccAccessKeyResourceNeverExpiresConfig() string {
 	return `
 resource "garage_access_key" "test" {
@@ -66,3 +124,43 @@ resource "garage_access_key" "test" {
 }
 `
 }
+
+func TestAccAccessKeyResourceImportKey(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: garage + testAccAccessKeyResourceImportKeyConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_access_key.test",
+						tfjsonpath.New("access_key_id"),
+						knownvalue.StringExact("GK31c2f218a2e44f485b94239e"),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_access_key.test",
+						tfjsonpath.New("secret_access_key"),
+						knownvalue.StringExact(
+							"b892c0665f0ada8a4755dae98baa3b133590e11dae3bcc1f9d769d67f16c3835",
+						),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccAccessKeyResourceImportKeyConfig() string {
+	return `
+resource "garage_access_key" "test" {
+	name = "bongo"
+	import_access_key_id = "GK31c2f218a2e44f485b94239e"
+	import_secret_access_key = "b892c0665f0ada8a4755dae98baa3b133590e11dae3bcc1f9d769d67f16c3835"
+}
+`
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AccessKeysDataSource{}
+
+func NewAccessKeysDataSource() datasource.DataSource {
+	return &AccessKeysDataSource{}
+}
+
+// AccessKeysDataSource defines the data source implementation.
+type AccessKeysDataSource struct {
+	client *client.Client
+}
+
+// AccessKeysDataSourceModel describes the data source data model.
+type AccessKeysDataSourceModel struct {
+	Keys []AccessKeysListItemModel `tfsdk:"keys"`
+}
+
+// AccessKeysListItemModel describes a single key in the list.
+type AccessKeysListItemModel struct {
+	ID         types.String `tfsdk:"id"`
+	Name       types.String `tfsdk:"name"`
+	Created    types.String `tfsdk:"created"`
+	Expiration types.String `tfsdk:"expiration"`
+	Expired    types.Bool   `tfsdk:"expired"`
+}
+
+func (d *AccessKeysDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_access_keys"
+}
+
+func (d *AccessKeysDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all access keys in the cluster",
+		Attributes: map[string]schema.Attribute{
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The access keys",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The access key id",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the access key",
+							Computed:            true,
+						},
+						"created": schema.StringAttribute{
+							MarkdownDescription: "The time in RFC 3339 format that the key was created",
+							Computed:            true,
+						},
+						"expiration": schema.StringAttribute{
+							MarkdownDescription: "The time in RFC 3339 format that the key expires, null when it never expires",
+							Computed:            true,
+						},
+						"expired": schema.BoolAttribute{
+							MarkdownDescription: "Whether the key has expired",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AccessKeysDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	setup, ok := req.ProviderData.(setupData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected setupData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = setup.client
+}
+
+func (d *AccessKeysDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data AccessKeysDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	keys, err := d.client.ListAccessKeys(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("could not list keys", err.Error())
+		return
+	}
+
+	data.Keys = []AccessKeysListItemModel{}
+	for _, key := range keys {
+		data.Keys = append(data.Keys, AccessKeysListItemModel{
+			ID:         types.StringValue(key.ID),
+			Name:       types.StringValue(key.Name),
+			Created:    types.StringPointerValue(key.Created),
+			Expiration: types.StringPointerValue(key.Expiration),
+			Expired:    types.BoolValue(key.Expired),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
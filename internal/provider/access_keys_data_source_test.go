@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccAccessKeysDataSource(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: garage + testAccAccessKeysDataSourceConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.garage_access_keys.test",
+						tfjsonpath.New("keys"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"name":    knownvalue.StringExact("bongo"),
+								"expired": knownvalue.Bool(false),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccAccessKeysDataSourceConfig() string {
+	return `
+resource "garage_access_key" "test" {
+	name = "bongo"
+	never_expires = true
+}
+data "garage_access_keys" "test" {
+	depends_on = [garage_access_key.test]
+}
+`
+}
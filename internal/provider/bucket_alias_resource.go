@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketAliasResource{}
+var _ resource.ResourceWithImportState = &BucketAliasResource{}
+
+func NewBucketAliasResource() resource.Resource {
+	return &BucketAliasResource{}
+}
+
+// BucketAliasResource defines the resource implementation.
+type BucketAliasResource struct {
+	client *client.Client
+}
+
+// BucketAliasResourceModel describes the resource data model.
+type BucketAliasResourceModel struct {
+	ID       types.String `tfsdk:"id"`
+	BucketID types.String `tfsdk:"bucket_id"`
+	Alias    types.String `tfsdk:"alias"`
+}
+
+func (r *BucketAliasResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_alias"
+}
+
+func (r *BucketAliasResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Global bucket alias resource",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the alias in format {bucketId}:{alias}",
+				Computed:            true,
+			},
+			"bucket_id": schema.StringAttribute{
+				MarkdownDescription: "The bucket id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"alias": schema.StringAttribute{
+				MarkdownDescription: "The global alias to add to the bucket",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BucketAliasResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	setup, ok := req.ProviderData.(setupData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected setupData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = setup.client
+}
+
+func (r *BucketAliasResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data BucketAliasResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.AddBucketAlias(ctx, client.BucketAliasRequest{
+		BucketID:    data.BucketID.ValueString(),
+		GlobalAlias: data.Alias.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("could not add bucket alias", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(
+		fmt.Sprintf("%s:%s", data.BucketID.ValueString(), data.Alias.ValueString()),
+	)
+
+	tflog.Trace(ctx, "created a bucket alias")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketAliasResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data BucketAliasResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spl := strings.Split(data.ID.ValueString(), ":")
+	if len(spl) != 2 {
+		resp.Diagnostics.AddError(
+			"invalid bucket alias id",
+			fmt.Sprintf("needs id in format {bucketId}:{alias}, got %s", data.ID.ValueString()),
+		)
+		return
+	}
+
+	bucket, err := r.client.GetBucket(ctx, spl[0])
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("could not get bucket", err.Error())
+		return
+	}
+
+	if !slices.Contains(bucket.GlobalAliases, spl[1]) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.BucketID = types.StringValue(bucket.ID)
+	data.Alias = types.StringValue(spl[1])
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketAliasResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data BucketAliasResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketAliasResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var data BucketAliasResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.RemoveBucketAlias(ctx, client.BucketAliasRequest{
+		BucketID:    data.BucketID.ValueString(),
+		GlobalAlias: data.Alias.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("could not remove bucket alias", err.Error())
+		return
+	}
+}
+
+func (r *BucketAliasResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
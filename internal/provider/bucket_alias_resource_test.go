@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccBucketAliasResource(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: garage + testAccBucketAliasResourceConfig("apple"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket_alias.test",
+						tfjsonpath.New("alias"),
+						knownvalue.StringExact("apple"),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "garage_bucket_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: garage + testAccBucketAliasResourceConfig("banana"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket_alias.test",
+						tfjsonpath.New("alias"),
+						knownvalue.StringExact("banana"),
+					),
+				},
+			},
+			// Refresh testing, the bucket picks up the new alias
+			{
+				Config: garage + testAccBucketAliasResourceConfig("banana"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("bongo"),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("global_aliases"),
+						knownvalue.SetExact([]knownvalue.Check{
+							knownvalue.StringExact("bongo"),
+							knownvalue.StringExact("banana"),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccBucketAliasResourceConfig(alias string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+	name = "bongo"
+}
+resource "garage_bucket_alias" "test" {
+	bucket_id = garage_bucket.test.id
+	alias = "%s"
+}
+`, alias)
+}
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketDataSource{}
+
+func NewBucketDataSource() datasource.DataSource {
+	return &BucketDataSource{}
+}
+
+// BucketDataSource defines the data source implementation.
+type BucketDataSource struct {
+	client *client.Client
+}
+
+// BucketDataSourceModel describes the data source data model.
+type BucketDataSourceModel struct {
+	ID                types.String        `tfsdk:"id"`
+	GlobalAlias       types.String        `tfsdk:"global_alias"`
+	Search            types.String        `tfsdk:"search"`
+	GlobalAliases     []types.String      `tfsdk:"global_aliases"`
+	LocalAliases      []LocalAliasModel   `tfsdk:"local_aliases"`
+	Quotas            *BucketQuotasModel  `tfsdk:"quotas"`
+	Website           *BucketWebsiteModel `tfsdk:"website"`
+	Objects           types.Int64         `tfsdk:"objects"`
+	Bytes             types.Int64         `tfsdk:"bytes"`
+	UnfinishedUploads types.Int64         `tfsdk:"unfinished_uploads"`
+	Keys              []BucketKeyModel    `tfsdk:"keys"`
+}
+
+// BucketKeyModel describes the permissions a key has on a bucket.
+type BucketKeyModel struct {
+	AccessKeyID types.String `tfsdk:"access_key_id"`
+	Name        types.String `tfsdk:"name"`
+	Owner       types.Bool   `tfsdk:"owner"`
+	Read        types.Bool   `tfsdk:"read"`
+	Write       types.Bool   `tfsdk:"write"`
+}
+
+func (d *BucketDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_bucket"
+}
+
+func (d *BucketDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Bucket data source, looked up by exactly one of `id`, `global_alias` or `search`",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the bucket",
+				Optional:            true,
+				Computed:            true,
+			},
+			"global_alias": schema.StringAttribute{
+				MarkdownDescription: "A global alias of the bucket",
+				Optional:            true,
+			},
+			"search": schema.StringAttribute{
+				MarkdownDescription: "A partial id or alias that matches a single bucket",
+				Optional:            true,
+			},
+			"global_aliases": schema.ListAttribute{
+				MarkdownDescription: "The global aliases of the bucket",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"local_aliases": schema.ListNestedAttribute{
+				MarkdownDescription: "The local aliases of the bucket",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"access_key_id": schema.StringAttribute{
+							MarkdownDescription: "The access key id the alias belongs to",
+							Computed:            true,
+						},
+						"alias": schema.StringAttribute{
+							MarkdownDescription: "The local alias",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"quotas": schema.SingleNestedAttribute{
+				MarkdownDescription: "The quotas applied to the bucket",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"max_size": schema.Int64Attribute{
+						MarkdownDescription: "The maximum size of the bucket in bytes",
+						Computed:            true,
+					},
+					"max_objects": schema.Int64Attribute{
+						MarkdownDescription: "The maximum number of objects in the bucket",
+						Computed:            true,
+					},
+				},
+			},
+			"website": schema.SingleNestedAttribute{
+				MarkdownDescription: "The website configuration, null when website access is disabled",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"index_document": schema.StringAttribute{
+						MarkdownDescription: "The document to serve for directory requests",
+						Computed:            true,
+					},
+					"error_document": schema.StringAttribute{
+						MarkdownDescription: "The document to serve when an object is not found",
+						Computed:            true,
+					},
+				},
+			},
+			"objects": schema.Int64Attribute{
+				MarkdownDescription: "The number of objects in the bucket",
+				Computed:            true,
+			},
+			"bytes": schema.Int64Attribute{
+				MarkdownDescription: "The total size of the objects in the bucket in bytes",
+				Computed:            true,
+			},
+			"unfinished_uploads": schema.Int64Attribute{
+				MarkdownDescription: "The number of unfinished uploads in the bucket",
+				Computed:            true,
+			},
+			"keys": schema.ListNestedAttribute{
+				MarkdownDescription: "The keys that have access to the bucket",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"access_key_id": schema.StringAttribute{
+							MarkdownDescription: "The access key id",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the access key",
+							Computed:            true,
+						},
+						"owner": schema.BoolAttribute{
+							MarkdownDescription: "Whether the key is the owner of the bucket",
+							Computed:            true,
+						},
+						"read": schema.BoolAttribute{
+							MarkdownDescription: "Whether the key can read from the bucket",
+							Computed:            true,
+						},
+						"write": schema.BoolAttribute{
+							MarkdownDescription: "Whether the key can write to the bucket",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BucketDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	setup, ok := req.ProviderData.(setupData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected setupData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = setup.client
+}
+
+func (d *BucketDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data BucketDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var (
+		bucket *client.Bucket
+		err    error
+	)
+	switch {
+	case !data.ID.IsNull() && data.GlobalAlias.IsNull() && data.Search.IsNull():
+		bucket, err = d.client.GetBucket(ctx, data.ID.ValueString())
+	case data.ID.IsNull() && !data.GlobalAlias.IsNull() && data.Search.IsNull():
+		bucket, err = d.client.GetBucketByGlobalAlias(ctx, data.GlobalAlias.ValueString())
+	case data.ID.IsNull() && data.GlobalAlias.IsNull() && !data.Search.IsNull():
+		bucket, err = d.client.SearchBucket(ctx, data.Search.ValueString())
+	default:
+		resp.Diagnostics.AddError(
+			"invalid input",
+			"exactly one of id, global_alias or search must be set",
+		)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("could not get bucket", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(bucket.ID)
+	data.GlobalAliases = []types.String{}
+	for _, alias := range bucket.GlobalAliases {
+		data.GlobalAliases = append(data.GlobalAliases, types.StringValue(alias))
+	}
+	data.LocalAliases = []LocalAliasModel{}
+	for _, alias := range bucket.LocalAliases() {
+		data.LocalAliases = append(data.LocalAliases, LocalAliasModel{
+			AccessKeyID: types.StringValue(alias.AccessKeyID),
+			Alias:       types.StringValue(alias.Alias),
+		})
+	}
+	data.Quotas = mapBucketQuotas(bucket)
+	data.Website = mapBucketWebsite(bucket)
+	data.Objects = types.Int64Value(bucket.Objects)
+	data.Bytes = types.Int64Value(bucket.Bytes)
+	data.UnfinishedUploads = types.Int64Value(bucket.UnfinishedUploads)
+	data.Keys = []BucketKeyModel{}
+	for _, key := range bucket.Keys {
+		data.Keys = append(data.Keys, BucketKeyModel{
+			AccessKeyID: types.StringValue(key.AccessKeyID),
+			Name:        types.StringValue(key.Name),
+			Owner:       types.BoolValue(key.Permissions.Owner),
+			Read:        types.BoolValue(key.Permissions.Read),
+			Write:       types.BoolValue(key.Permissions.Write),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccBucketDataSource(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: garage + testAccBucketDataSourceConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.garage_bucket.by_alias",
+						tfjsonpath.New("global_aliases"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("bongo"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.garage_bucket.by_alias",
+						tfjsonpath.New("quotas").AtMapKey("max_objects"),
+						knownvalue.Int64Exact(100),
+					),
+					statecheck.ExpectKnownValue(
+						"data.garage_bucket.by_alias",
+						tfjsonpath.New("objects"),
+						knownvalue.Int64Exact(0),
+					),
+					statecheck.ExpectKnownValue(
+						"data.garage_bucket.by_id",
+						tfjsonpath.New("global_aliases"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("bongo"),
+						}),
+					),
+					statecheck.ExpectKnownValue(
+						"data.garage_bucket.by_search",
+						tfjsonpath.New("global_aliases"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("bongo"),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccBucketDataSourceConfig() string {
+	return `
+resource "garage_bucket" "test" {
+	name = "bongo"
+	quotas = {
+		max_objects = 100
+	}
+}
+data "garage_bucket" "by_alias" {
+	global_alias = garage_bucket.test.name
+}
+data "garage_bucket" "by_id" {
+	id = garage_bucket.test.id
+}
+data "garage_bucket" "by_search" {
+	search = "bon"
+	depends_on = [garage_bucket.test]
+}
+`
+}
@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketLocalAliasResource{}
+var _ resource.ResourceWithImportState = &BucketLocalAliasResource{}
+
+func NewBucketLocalAliasResource() resource.Resource {
+	return &BucketLocalAliasResource{}
+}
+
+// BucketLocalAliasResource defines the resource implementation.
+type BucketLocalAliasResource struct {
+	client *client.Client
+}
+
+// BucketLocalAliasResourceModel describes the resource data model.
+type BucketLocalAliasResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	BucketID    types.String `tfsdk:"bucket_id"`
+	AccessKeyID types.String `tfsdk:"access_key_id"`
+	Alias       types.String `tfsdk:"alias"`
+}
+
+func (r *BucketLocalAliasResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_local_alias"
+}
+
+func (r *BucketLocalAliasResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Local bucket alias resource, scoped to an access key",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the alias in format {bucketId}:{accessKeyId}:{alias}",
+				Computed:            true,
+			},
+			"bucket_id": schema.StringAttribute{
+				MarkdownDescription: "The bucket id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"access_key_id": schema.StringAttribute{
+				MarkdownDescription: "The access key id the alias belongs to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"alias": schema.StringAttribute{
+				MarkdownDescription: "The local alias to add to the bucket",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *BucketLocalAliasResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	setup, ok := req.ProviderData.(setupData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected setupData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = setup.client
+}
+
+func (r *BucketLocalAliasResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data BucketLocalAliasResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.AddBucketAlias(ctx, client.BucketAliasRequest{
+		BucketID:    data.BucketID.ValueString(),
+		AccessKeyID: data.AccessKeyID.ValueString(),
+		LocalAlias:  data.Alias.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("could not add bucket local alias", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(fmt.Sprintf(
+		"%s:%s:%s",
+		data.BucketID.ValueString(),
+		data.AccessKeyID.ValueString(),
+		data.Alias.ValueString(),
+	))
+
+	tflog.Trace(ctx, "created a bucket local alias")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketLocalAliasResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data BucketLocalAliasResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spl := strings.Split(data.ID.ValueString(), ":")
+	if len(spl) != 3 {
+		resp.Diagnostics.AddError(
+			"invalid bucket local alias id",
+			fmt.Sprintf(
+				"needs id in format {bucketId}:{accessKeyId}:{alias}, got %s",
+				data.ID.ValueString(),
+			),
+		)
+		return
+	}
+
+	bucket, err := r.client.GetBucket(ctx, spl[0])
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("could not get bucket", err.Error())
+		return
+	}
+
+	alias := client.LocalAlias{AccessKeyID: spl[1], Alias: spl[2]}
+	if !slices.Contains(bucket.LocalAliases(), alias) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.BucketID = types.StringValue(bucket.ID)
+	data.AccessKeyID = types.StringValue(alias.AccessKeyID)
+	data.Alias = types.StringValue(alias.Alias)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketLocalAliasResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data BucketLocalAliasResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketLocalAliasResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var data BucketLocalAliasResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.client.RemoveBucketAlias(ctx, client.BucketAliasRequest{
+		BucketID:    data.BucketID.ValueString(),
+		AccessKeyID: data.AccessKeyID.ValueString(),
+		LocalAlias:  data.Alias.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("could not remove bucket local alias", err.Error())
+		return
+	}
+}
+
+func (r *BucketLocalAliasResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
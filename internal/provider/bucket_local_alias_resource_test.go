@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccBucketLocalAliasResource(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: garage + testAccBucketLocalAliasResourceConfig("apple"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("name"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("local_alias").AtMapKey("alias"),
+						knownvalue.StringExact("bongo"),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_bucket_local_alias.test",
+						tfjsonpath.New("alias"),
+						knownvalue.StringExact("apple"),
+					),
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "garage_bucket_local_alias.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Update and Read testing
+			{
+				Config: garage + testAccBucketLocalAliasResourceConfig("banana"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket_local_alias.test",
+						tfjsonpath.New("alias"),
+						knownvalue.StringExact("banana"),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccBucketLocalAliasResourceConfig(alias string) string {
+	return fmt.Sprintf(`
+resource "garage_access_key" "test" {
+	name = "apple"
+}
+resource "garage_bucket" "test" {
+	local_alias = {
+		access_key_id = garage_access_key.test.id
+		alias = "bongo"
+	}
+}
+resource "garage_bucket_local_alias" "test" {
+	bucket_id = garage_bucket.test.id
+	access_key_id = garage_access_key.test.id
+	alias = "%s"
+}
+`, alias)
+}
@@ -0,0 +1,313 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &BucketPermissionsResource{}
+var _ resource.ResourceWithImportState = &BucketPermissionsResource{}
+
+func NewBucketPermissionsResource() resource.Resource {
+	return &BucketPermissionsResource{}
+}
+
+// BucketPermissionsResource defines the resource implementation.
+type BucketPermissionsResource struct {
+	client *client.Client
+}
+
+// BucketPermissionsResourceModel describes the resource data model.
+type BucketPermissionsResourceModel struct {
+	ID       types.String                `tfsdk:"id"`
+	BucketID types.String                `tfsdk:"bucket_id"`
+	Keys     map[string]PermissionsModel `tfsdk:"keys"`
+}
+
+// PermissionsModel describes the permissions a key has on a bucket.
+type PermissionsModel struct {
+	Owner types.Bool `tfsdk:"owner"`
+	Read  types.Bool `tfsdk:"read"`
+	Write types.Bool `tfsdk:"write"`
+}
+
+func (r *BucketPermissionsResource) Metadata(
+	ctx context.Context,
+	req resource.MetadataRequest,
+	resp *resource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_bucket_permissions"
+}
+
+func (r *BucketPermissionsResource) Schema(
+	ctx context.Context,
+	req resource.SchemaRequest,
+	resp *resource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages every key's permissions on a bucket, access is revoked from any key that isn't listed. " +
+			"Don't use it together with `garage_permission` or `garage_access_key_buckets` for the same bucket",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The id of the bucket",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket_id": schema.StringAttribute{
+				MarkdownDescription: "The bucket id",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"keys": schema.MapNestedAttribute{
+				MarkdownDescription: "The permissions of each key on the bucket, keyed by access key id",
+				Required:            true,
+				NestedObject:        permissionsNestedObject(),
+			},
+		},
+	}
+}
+
+// permissionsNestedObject is the owner/read/write object shared by the
+// authoritative permission resources.
+func permissionsNestedObject() schema.NestedAttributeObject {
+	return schema.NestedAttributeObject{
+		Attributes: map[string]schema.Attribute{
+			"owner": schema.BoolAttribute{
+				MarkdownDescription: "Whether the key is the owner of the bucket",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"read": schema.BoolAttribute{
+				MarkdownDescription: "Whether the key can read from the bucket",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+			"write": schema.BoolAttribute{
+				MarkdownDescription: "Whether the key can write to the bucket",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(false),
+			},
+		},
+	}
+}
+
+func (r *BucketPermissionsResource) Configure(
+	ctx context.Context,
+	req resource.ConfigureRequest,
+	resp *resource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	setup, ok := req.ProviderData.(setupData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf(
+				"Expected setupData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	r.client = setup.client
+}
+
+func (r *BucketPermissionsResource) Create(
+	ctx context.Context,
+	req resource.CreateRequest,
+	resp *resource.CreateResponse,
+) {
+	var data BucketPermissionsResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket, err := r.client.SetBucketPermissions(
+		ctx,
+		data.BucketID.ValueString(),
+		permissionsRequest(data.Keys),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("could not set bucket permissions", err.Error())
+		return
+	}
+
+	mapBucketPermissionsToData(&data, bucket)
+
+	tflog.Trace(ctx, "created bucket permissions")
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketPermissionsResource) Read(
+	ctx context.Context,
+	req resource.ReadRequest,
+	resp *resource.ReadResponse,
+) {
+	var data BucketPermissionsResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket, err := r.client.GetBucket(ctx, data.ID.ValueString())
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("could not get bucket", err.Error())
+		return
+	}
+
+	mapBucketPermissionsToData(&data, bucket)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketPermissionsResource) Update(
+	ctx context.Context,
+	req resource.UpdateRequest,
+	resp *resource.UpdateResponse,
+) {
+	var data BucketPermissionsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bucket, err := r.client.SetBucketPermissions(
+		ctx,
+		data.BucketID.ValueString(),
+		permissionsRequest(data.Keys),
+	)
+	if err != nil {
+		resp.Diagnostics.AddError("could not set bucket permissions", err.Error())
+		return
+	}
+
+	mapBucketPermissionsToData(&data, bucket)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *BucketPermissionsResource) Delete(
+	ctx context.Context,
+	req resource.DeleteRequest,
+	resp *resource.DeleteResponse,
+) {
+	var data BucketPermissionsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for keyID := range data.Keys {
+		err := r.client.DeletePermission(ctx, keyID, data.BucketID.ValueString())
+		if client.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			resp.Diagnostics.AddError("could not delete permissions", err.Error())
+			return
+		}
+	}
+}
+
+func (r *BucketPermissionsResource) ImportState(
+	ctx context.Context,
+	req resource.ImportStateRequest,
+	resp *resource.ImportStateResponse,
+) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+func permissionsRequest(perms map[string]PermissionsModel) map[string]client.CreatePermissionsBlock {
+	out := map[string]client.CreatePermissionsBlock{}
+	for id, perm := range perms {
+		out[id] = client.CreatePermissionsBlock{
+			Owner: perm.Owner.ValueBool(),
+			Read:  perm.Read.ValueBool(),
+			Write: perm.Write.ValueBool(),
+		}
+	}
+	return out
+}
+
+// mapBucketPermissionsToData maps the keys with access to the bucket, see
+// mapGrants.
+func mapBucketPermissionsToData(data *BucketPermissionsResourceModel, bucket *client.Bucket) {
+	grants := map[string]client.CreatePermissionsBlock{}
+	for _, key := range bucket.Keys {
+		grants[key.AccessKeyID] = client.CreatePermissionsBlock(key.Permissions)
+	}
+
+	data.ID = types.StringValue(bucket.ID)
+	data.BucketID = types.StringValue(bucket.ID)
+	data.Keys = mapGrants(data.Keys, grants)
+}
+
+// mapGrants keeps the configured entries, so entries without any permissions
+// don't show a diff, and adds any other grant with permissions so it shows up
+// as drift.
+func mapGrants(
+	configured map[string]PermissionsModel,
+	grants map[string]client.CreatePermissionsBlock,
+) map[string]PermissionsModel {
+	out := map[string]PermissionsModel{}
+	for id := range configured {
+		out[id] = PermissionsModel{
+			Owner: types.BoolValue(false),
+			Read:  types.BoolValue(false),
+			Write: types.BoolValue(false),
+		}
+	}
+	for id, perms := range grants {
+		_, managed := configured[id]
+		if !managed && !perms.Owner && !perms.Read && !perms.Write {
+			continue
+		}
+		out[id] = PermissionsModel{
+			Owner: types.BoolValue(perms.Owner),
+			Read:  types.BoolValue(perms.Read),
+			Write: types.BoolValue(perms.Write),
+		}
+	}
+	return out
+}
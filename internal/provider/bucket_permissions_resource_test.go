@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+func TestAccBucketPermissionsResource(t *testing.T) {
+	garage, c, cancel := garageWithClient(t)
+	defer cancel()
+
+	var bucketID, strayID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: garage + testAccBucketPermissionsResourceConfig(true),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket_permissions.test",
+						tfjsonpath.New("keys"),
+						knownvalue.MapSizeExact(2),
+					),
+				},
+				Check: func(s *terraform.State) error {
+					bucketID = s.RootModule().Resources["garage_bucket.test"].Primary.ID
+					strayID = s.RootModule().Resources["garage_access_key.stray"].Primary.ID
+					return nil
+				},
+			},
+			// ImportState testing
+			{
+				ResourceName:      "garage_bucket_permissions.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Grants made outside of terraform are revoked
+			{
+				PreConfig: func() {
+					_, err := c.CreatePermission(t.Context(), client.CreatePermissionRequest{
+						AccessKeyID: strayID,
+						BucketID:    bucketID,
+						Permissions: client.CreatePermissionsBlock{Read: true},
+					})
+					if err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: garage + testAccBucketPermissionsResourceConfig(true),
+				Check: func(s *terraform.State) error {
+					return testAccCheckNoAccess(t, c, strayID, bucketID)
+				},
+			},
+			// Update and Read testing
+			{
+				Config: garage + testAccBucketPermissionsResourceConfig(false),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket_permissions.test",
+						tfjsonpath.New("keys"),
+						knownvalue.MapSizeExact(1),
+					),
+				},
+				Check: func(s *terraform.State) error {
+					reader := s.RootModule().Resources["garage_access_key.reader"].Primary.ID
+					return testAccCheckNoAccess(t, c, reader, bucketID)
+				},
+			},
+		},
+	})
+}
+
+// testAccCheckNoAccess checks the key has no permissions on the bucket.
+func testAccCheckNoAccess(t *testing.T, c *client.Client, keyID, bucketID string) error {
+	perms, err := c.GetPermissions(t.Context(), keyID, bucketID)
+	if client.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if perms.Owner || perms.Read || perms.Write {
+		return fmt.Errorf("key %s still has access to the bucket: %+v", keyID, perms)
+	}
+	return nil
+}
+
+func testAccBucketPermissionsResourceConfig(reader bool) string {
+	readerKey := ""
+	if reader {
+		readerKey = `
+		(garage_access_key.reader.id) = {
+			read = true
+		}`
+	}
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+	name = "bongo"
+}
+resource "garage_access_key" "writer" {
+	name = "writer"
+}
+resource "garage_access_key" "reader" {
+	name = "reader"
+}
+resource "garage_access_key" "stray" {
+	name = "stray"
+}
+resource "garage_bucket_permissions" "test" {
+	bucket_id = garage_bucket.test.id
+	keys = {
+		(garage_access_key.writer.id) = {
+			read = true
+			write = true
+		}%s
+	}
+}
+`, readerKey)
+}
@@ -3,12 +3,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
@@ -17,6 +19,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &BucketResource{}
 var _ resource.ResourceWithImportState = &BucketResource{}
+var _ resource.ResourceWithModifyPlan = &BucketResource{}
 
 func NewBucketResource() resource.Resource {
 	return &BucketResource{}
@@ -29,8 +32,30 @@ type BucketResource struct {
 
 // BucketResourceModel describes the resource data model.
 type BucketResourceModel struct {
-	ID   types.String `tfsdk:"id"`
-	Name types.String `tfsdk:"name"`
+	ID            types.String        `tfsdk:"id"`
+	Name          types.String        `tfsdk:"name"`
+	GlobalAliases types.List          `tfsdk:"global_aliases"`
+	LocalAlias    *LocalAliasModel    `tfsdk:"local_alias"`
+	Quotas        *BucketQuotasModel  `tfsdk:"quotas"`
+	Website       *BucketWebsiteModel `tfsdk:"website"`
+}
+
+// LocalAliasModel describes a bucket alias scoped to an access key.
+type LocalAliasModel struct {
+	AccessKeyID types.String `tfsdk:"access_key_id"`
+	Alias       types.String `tfsdk:"alias"`
+}
+
+// BucketQuotasModel describes the quotas applied to a bucket.
+type BucketQuotasModel struct {
+	MaxSize    types.Int64 `tfsdk:"max_size"`
+	MaxObjects types.Int64 `tfsdk:"max_objects"`
+}
+
+// BucketWebsiteModel describes the website configuration of a bucket.
+type BucketWebsiteModel struct {
+	IndexDocument types.String `tfsdk:"index_document"`
+	ErrorDocument types.String `tfsdk:"error_document"`
 }
 
 func (r *BucketResource) Metadata(
@@ -54,10 +79,57 @@ func (r *BucketResource) Schema(
 				Computed:            true,
 			},
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Name of the bucket",
-				Required:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+				MarkdownDescription: "Name of the bucket, used as its global alias. Required unless `local_alias` is set",
+				Optional:            true,
+			},
+			"global_aliases": schema.ListAttribute{
+				MarkdownDescription: "All global aliases of the bucket, including the name",
+				ElementType:         types.StringType,
+				Computed:            true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"local_alias": schema.SingleNestedAttribute{
+				MarkdownDescription: "A local alias of the bucket scoped to an access key",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"access_key_id": schema.StringAttribute{
+						MarkdownDescription: "The access key id the alias belongs to",
+						Required:            true,
+					},
+					"alias": schema.StringAttribute{
+						MarkdownDescription: "The local alias",
+						Required:            true,
+					},
+				},
+			},
+			"quotas": schema.SingleNestedAttribute{
+				MarkdownDescription: "Quotas to apply to the bucket",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"max_size": schema.Int64Attribute{
+						MarkdownDescription: "The maximum size of the bucket in bytes",
+						Optional:            true,
+					},
+					"max_objects": schema.Int64Attribute{
+						MarkdownDescription: "The maximum number of objects in the bucket",
+						Optional:            true,
+					},
+				},
+			},
+			"website": schema.SingleNestedAttribute{
+				MarkdownDescription: "Serve the bucket as a website, website access is disabled when unset",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"index_document": schema.StringAttribute{
+						MarkdownDescription: "The document to serve for directory requests, i.e.: index.html",
+						Required:            true,
+					},
+					"error_document": schema.StringAttribute{
+						MarkdownDescription: "The document to serve when an object is not found",
+						Optional:            true,
+					},
 				},
 			},
 		},
@@ -102,14 +174,47 @@ func (r *BucketResource) Create(
 		return
 	}
 
-	bucket, err := r.client.CreateBucket(ctx, data.Name.ValueString())
+	if data.Name.IsNull() && data.LocalAlias == nil {
+		resp.Diagnostics.AddError(
+			"invalid input",
+			"one of name or local_alias must be set",
+		)
+		return
+	}
+
+	create := client.CreateBucketRequest{
+		GlobalAlias: data.Name.ValueString(),
+	}
+	if data.LocalAlias != nil {
+		create.LocalAlias = &client.LocalAlias{
+			AccessKeyID: data.LocalAlias.AccessKeyID.ValueString(),
+			Alias:       data.LocalAlias.Alias.ValueString(),
+		}
+	}
+
+	bucket, err := r.client.CreateBucket(ctx, create)
 	if err != nil {
 		resp.Diagnostics.AddError("failed to create bucket", fmt.Sprintf("got error: %s", err))
 		return
 	}
 
-	data.ID = types.StringValue(bucket.ID)
-	data.Name = types.StringValue(bucket.GlobalAliases[0])
+	if data.Quotas != nil || data.Website != nil {
+		updated, err := r.client.UpdateBucket(ctx, bucket.ID, bucketUpdateRequest(&data))
+		if err != nil {
+			resp.Diagnostics.AddError("failed to configure bucket", fmt.Sprintf("got error: %s", err))
+			// Remove the new bucket so its alias doesn't block the next apply,
+			// or keep it in state when that fails so it is replaced instead
+			if err := r.client.DeleteBucket(ctx, bucket.ID); err != nil {
+				resp.Diagnostics.AddError("could not remove partially created bucket", err.Error())
+				resp.Diagnostics.Append(mapBucketToData(ctx, &data, bucket)...)
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+			}
+			return
+		}
+		bucket = updated
+	}
+
+	resp.Diagnostics.Append(mapBucketToData(ctx, &data, bucket)...)
 
 	tflog.Trace(ctx, "created a bucket")
 
@@ -131,6 +236,11 @@ func (r *BucketResource) Read(
 	}
 
 	bucket, err := r.client.GetBucket(ctx, data.ID.ValueString())
+	if client.IsNotFound(err) {
+		// Deleted outside of terraform, so remove it to be recreated
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"could not get bucket",
@@ -139,8 +249,7 @@ func (r *BucketResource) Read(
 		return
 	}
 
-	data.ID = types.StringValue(bucket.ID)
-	data.Name = types.StringValue(bucket.GlobalAliases[0])
+	resp.Diagnostics.Append(mapBucketToData(ctx, &data, bucket)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -151,19 +260,214 @@ func (r *BucketResource) Update(
 	req resource.UpdateRequest,
 	resp *resource.UpdateResponse,
 ) {
-	var data BucketResourceModel
+	var data, state BucketResourceModel
 
 	// Read Terraform plan data into the model
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	id := data.ID.ValueString()
+	renamed := !data.Name.Equal(state.Name)
+	realiased := !localAliasEqual(data.LocalAlias, state.LocalAlias)
+	// Add the new aliases before removing the old ones, so the bucket is never
+	// left without an alias
+	if realiased && data.LocalAlias != nil {
+		if err := r.swapAlias(ctx, localAliasRequest(id, state.LocalAlias), localAliasRequest(id, data.LocalAlias)); err != nil {
+			resp.Diagnostics.AddError("could not update bucket local alias", err.Error())
+			return
+		}
+		realiased = false
+	}
+	if renamed {
+		if err := r.swapAlias(ctx, globalAliasRequest(id, state.Name), globalAliasRequest(id, data.Name)); err != nil {
+			resp.Diagnostics.AddError("could not rename bucket", err.Error())
+			return
+		}
+	}
+	if realiased {
+		if err := r.swapAlias(ctx, localAliasRequest(id, state.LocalAlias), nil); err != nil {
+			resp.Diagnostics.AddError("could not update bucket local alias", err.Error())
+			return
+		}
+	}
+
+	bucket, err := r.client.UpdateBucket(ctx, data.ID.ValueString(), bucketUpdateRequest(&data))
+	if err != nil {
+		resp.Diagnostics.AddError("could not update bucket", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(mapBucketToData(ctx, &data, bucket)...)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// swapAlias replaces an alias of the bucket, either of which can be nil. The
+// new alias is added before the old one is removed so the bucket is always
+// reachable, and is rolled back if the old alias can't be removed.
+func (r *BucketResource) swapAlias(ctx context.Context, from, to *client.BucketAliasRequest) error {
+	if to != nil {
+		if _, err := r.client.AddBucketAlias(ctx, *to); err != nil {
+			return err
+		}
+	}
+	if from == nil {
+		return nil
+	}
+
+	_, err := r.client.RemoveBucketAlias(ctx, *from)
+	if err != nil && to != nil {
+		if _, rerr := r.client.RemoveBucketAlias(ctx, *to); rerr != nil {
+			return fmt.Errorf("%w, rollback failed: %w", err, rerr)
+		}
+	}
+	return err
+}
+
+func globalAliasRequest(id string, name types.String) *client.BucketAliasRequest {
+	if name.IsNull() {
+		return nil
+	}
+	return &client.BucketAliasRequest{
+		BucketID:    id,
+		GlobalAlias: name.ValueString(),
+	}
+}
+
+func localAliasRequest(id string, alias *LocalAliasModel) *client.BucketAliasRequest {
+	if alias == nil {
+		return nil
+	}
+	return &client.BucketAliasRequest{
+		BucketID:    id,
+		AccessKeyID: alias.AccessKeyID.ValueString(),
+		LocalAlias:  alias.Alias.ValueString(),
+	}
+}
+
+func localAliasEqual(a, b *LocalAliasModel) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.AccessKeyID.Equal(b.AccessKeyID) && a.Alias.Equal(b.Alias)
+}
+
+// ModifyPlan marks global_aliases as unknown when the bucket is renamed, as
+// it is otherwise kept from the state.
+func (r *BucketResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing changes when creating or destroying the bucket
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state BucketResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.Name.Equal(state.Name) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(
+		ctx,
+		path.Root("global_aliases"),
+		types.ListUnknown(types.StringType),
+	)...)
+}
+
+func bucketUpdateRequest(data *BucketResourceModel) client.UpdateBucketRequest {
+	req := client.UpdateBucketRequest{
+		Quotas: &client.BucketQuotas{},
+	}
+	if data.Quotas != nil {
+		req.Quotas.MaxSize = data.Quotas.MaxSize.ValueInt64Pointer()
+		req.Quotas.MaxObjects = data.Quotas.MaxObjects.ValueInt64Pointer()
+	}
+	req.WebsiteAccess = &client.UpdateBucketWebsiteAccess{}
+	if data.Website != nil {
+		req.WebsiteAccess.Enabled = true
+		req.WebsiteAccess.IndexDocument = data.Website.IndexDocument.ValueStringPointer()
+		req.WebsiteAccess.ErrorDocument = data.Website.ErrorDocument.ValueStringPointer()
+	}
+	return req
+}
+
+func mapBucketToData(
+	ctx context.Context,
+	data *BucketResourceModel,
+	bucket *client.Bucket,
+) diag.Diagnostics {
+	data.ID = types.StringValue(bucket.ID)
+	// Buckets can have many global aliases, so keep the configured name as
+	// long as it is still one of them
+	if !slices.Contains(bucket.GlobalAliases, data.Name.ValueString()) {
+		data.Name = types.StringNull()
+		if data.LocalAlias == nil && len(bucket.GlobalAliases) > 0 {
+			data.Name = types.StringValue(bucket.GlobalAliases[0])
+		}
+	}
+	localAliases := bucket.LocalAliases()
+	if data.LocalAlias != nil && !slices.Contains(localAliases, client.LocalAlias{
+		AccessKeyID: data.LocalAlias.AccessKeyID.ValueString(),
+		Alias:       data.LocalAlias.Alias.ValueString(),
+	}) {
+		data.LocalAlias = nil
+	}
+	// Only fall back to a local alias for buckets that have no name, i.e.
+	// when importing a bucket that was created with a local alias
+	if data.LocalAlias == nil && data.Name.IsNull() && len(localAliases) > 0 {
+		data.LocalAlias = &LocalAliasModel{
+			AccessKeyID: types.StringValue(localAliases[0].AccessKeyID),
+			Alias:       types.StringValue(localAliases[0].Alias),
+		}
+	}
+	aliases, diags := types.ListValueFrom(ctx, types.StringType, bucket.GlobalAliases)
+	data.GlobalAliases = aliases
+	quotas := mapBucketQuotas(bucket)
+	// An empty quotas block means no quotas, keep it when it is configured so
+	// the state matches the plan
+	if quotas == nil && data.Quotas != nil {
+		quotas = &BucketQuotasModel{
+			MaxSize:    types.Int64Null(),
+			MaxObjects: types.Int64Null(),
+		}
+	}
+	data.Quotas = quotas
+	data.Website = mapBucketWebsite(bucket)
+	return diags
+}
+
+func mapBucketQuotas(bucket *client.Bucket) *BucketQuotasModel {
+	if bucket.Quotas.MaxSize == nil && bucket.Quotas.MaxObjects == nil {
+		return nil
+	}
+	return &BucketQuotasModel{
+		MaxSize:    types.Int64PointerValue(bucket.Quotas.MaxSize),
+		MaxObjects: types.Int64PointerValue(bucket.Quotas.MaxObjects),
+	}
+}
+
+func mapBucketWebsite(bucket *client.Bucket) *BucketWebsiteModel {
+	if !bucket.WebsiteAccess || bucket.WebsiteConfig == nil {
+		return nil
+	}
+	return &BucketWebsiteModel{
+		IndexDocument: types.StringValue(bucket.WebsiteConfig.IndexDocument),
+		ErrorDocument: types.StringPointerValue(bucket.WebsiteConfig.ErrorDocument),
+	}
+}
+
 func (r *BucketResource) Delete(
 	ctx context.Context,
 	req resource.DeleteRequest,
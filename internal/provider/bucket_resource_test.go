@@ -1,10 +1,13 @@
 package provider
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
@@ -13,6 +16,8 @@ func TestAccBucketResource(t *testing.T) {
 	garage, cancel := garage(t)
 	defer cancel()
 
+	sameID := statecheck.CompareValue(compare.ValuesSame())
+
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { testAccPreCheck(t) },
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
@@ -21,6 +26,7 @@ func TestAccBucketResource(t *testing.T) {
 			{
 				Config: garage + testAccBucketResourceConfig(),
 				ConfigStateChecks: []statecheck.StateCheck{
+					sameID.AddStateValue("garage_bucket.test", tfjsonpath.New("id")),
 					statecheck.ExpectKnownValue(
 						"garage_bucket.test",
 						tfjsonpath.New("name"),
@@ -45,6 +51,130 @@ func TestAccBucketResource(t *testing.T) {
 					),
 				},
 			},
+			// Quotas update testing
+			{
+				Config: garage + testAccBucketResourceQuotasConfig(1073741824, 100),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("quotas").AtMapKey("max_size"),
+						knownvalue.Int64Exact(1073741824),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("quotas").AtMapKey("max_objects"),
+						knownvalue.Int64Exact(100),
+					),
+				},
+			},
+			// Website update testing
+			{
+				Config: garage + testAccBucketResourceWebsiteConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("website").AtMapKey("index_document"),
+						knownvalue.StringExact("index.html"),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("website").AtMapKey("error_document"),
+						knownvalue.StringExact("404.html"),
+					),
+				},
+			},
+			// Quotas and website removal testing
+			{
+				Config: garage + testAccBucketResourceConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("quotas"),
+						knownvalue.Null(),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("website"),
+						knownvalue.Null(),
+					),
+				},
+			},
+			// Empty quotas testing
+			{
+				Config: garage + testAccBucketResourceEmptyQuotasConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("quotas"),
+						knownvalue.ObjectExact(map[string]knownvalue.Check{
+							"max_size":    knownvalue.Null(),
+							"max_objects": knownvalue.Null(),
+						}),
+					),
+				},
+			},
+			// Rename testing, the bucket is kept
+			{
+				Config: garage + testAccBucketResourceRenamedConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					sameID.AddStateValue("garage_bucket.test", tfjsonpath.New("id")),
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("name"),
+						knownvalue.StringExact("bingo"),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("global_aliases"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.StringExact("bingo"),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func TestAccBucketResourceLocalAlias(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	sameID := statecheck.CompareValue(compare.ValuesSame())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: garage + testAccBucketResourceLocalAliasConfig("bongo"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					sameID.AddStateValue("garage_bucket.test", tfjsonpath.New("id")),
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("local_alias").AtMapKey("alias"),
+						knownvalue.StringExact("bongo"),
+					),
+				},
+			},
+			// Local alias update testing, the bucket is kept
+			{
+				Config: garage + testAccBucketResourceLocalAliasConfig("bingo"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_bucket.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					sameID.AddStateValue("garage_bucket.test", tfjsonpath.New("id")),
+					statecheck.ExpectKnownValue(
+						"garage_bucket.test",
+						tfjsonpath.New("local_alias").AtMapKey("alias"),
+						knownvalue.StringExact("bingo"),
+					),
+				},
+			},
 		},
 	})
 }
@@ -56,3 +186,58 @@ resource "garage_bucket" "test" {
 }
 `
 }
+
+func testAccBucketResourceRenamedConfig() string {
+	return `
+resource "garage_bucket" "test" {
+	name = "bingo"
+}
+`
+}
+
+func testAccBucketResourceQuotasConfig(maxSize, maxObjects int) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+	name = "bongo"
+	quotas = {
+		max_size = %d
+		max_objects = %d
+	}
+}
+`, maxSize, maxObjects)
+}
+
+func testAccBucketResourceEmptyQuotasConfig() string {
+	return `
+resource "garage_bucket" "test" {
+	name = "bongo"
+	quotas = {}
+}
+`
+}
+
+func testAccBucketResourceWebsiteConfig() string {
+	return `
+resource "garage_bucket" "test" {
+	name = "bongo"
+	website = {
+		index_document = "index.html"
+		error_document = "404.html"
+	}
+}
+`
+}
+
+func testAccBucketResourceLocalAliasConfig(alias string) string {
+	return fmt.Sprintf(`
+resource "garage_access_key" "test" {
+	name = "apple"
+}
+resource "garage_bucket" "test" {
+	local_alias = {
+		access_key_id = garage_access_key.test.id
+		alias = "%s"
+	}
+}
+`, alias)
+}
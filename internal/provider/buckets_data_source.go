@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &BucketsDataSource{}
+
+func NewBucketsDataSource() datasource.DataSource {
+	return &BucketsDataSource{}
+}
+
+// BucketsDataSource defines the data source implementation.
+type BucketsDataSource struct {
+	client *client.Client
+}
+
+// BucketsDataSourceModel describes the data source data model.
+type BucketsDataSourceModel struct {
+	Prefix  types.String           `tfsdk:"prefix"`
+	Buckets []BucketsListItemModel `tfsdk:"buckets"`
+}
+
+// BucketsListItemModel describes a single bucket in the list.
+type BucketsListItemModel struct {
+	ID            types.String      `tfsdk:"id"`
+	GlobalAliases []types.String    `tfsdk:"global_aliases"`
+	LocalAliases  []LocalAliasModel `tfsdk:"local_aliases"`
+}
+
+func (d *BucketsDataSource) Metadata(
+	ctx context.Context,
+	req datasource.MetadataRequest,
+	resp *datasource.MetadataResponse,
+) {
+	resp.TypeName = req.ProviderTypeName + "_buckets"
+}
+
+func (d *BucketsDataSource) Schema(
+	ctx context.Context,
+	req datasource.SchemaRequest,
+	resp *datasource.SchemaResponse,
+) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Lists all buckets in the cluster",
+		Attributes: map[string]schema.Attribute{
+			"prefix": schema.StringAttribute{
+				MarkdownDescription: "Only include buckets with a global or local alias starting with the prefix",
+				Optional:            true,
+			},
+			"buckets": schema.ListNestedAttribute{
+				MarkdownDescription: "The buckets",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The id of the bucket",
+							Computed:            true,
+						},
+						"global_aliases": schema.ListAttribute{
+							MarkdownDescription: "The global aliases of the bucket",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+						"local_aliases": schema.ListNestedAttribute{
+							MarkdownDescription: "The local aliases of the bucket",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"access_key_id": schema.StringAttribute{
+										MarkdownDescription: "The access key id the alias belongs to",
+										Computed:            true,
+									},
+									"alias": schema.StringAttribute{
+										MarkdownDescription: "The local alias",
+										Computed:            true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *BucketsDataSource) Configure(
+	ctx context.Context,
+	req datasource.ConfigureRequest,
+	resp *datasource.ConfigureResponse,
+) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	setup, ok := req.ProviderData.(setupData)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf(
+				"Expected setupData, got: %T. Please report this issue to the provider developers.",
+				req.ProviderData,
+			),
+		)
+
+		return
+	}
+
+	d.client = setup.client
+}
+
+func (d *BucketsDataSource) Read(
+	ctx context.Context,
+	req datasource.ReadRequest,
+	resp *datasource.ReadResponse,
+) {
+	var data BucketsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	buckets, err := d.client.ListBuckets(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("could not list buckets", err.Error())
+		return
+	}
+
+	data.Buckets = []BucketsListItemModel{}
+	for _, bucket := range buckets {
+		if !bucketHasAliasPrefix(bucket, data.Prefix.ValueString()) {
+			continue
+		}
+		item := BucketsListItemModel{
+			ID:            types.StringValue(bucket.ID),
+			GlobalAliases: []types.String{},
+			LocalAliases:  []LocalAliasModel{},
+		}
+		for _, alias := range bucket.GlobalAliases {
+			item.GlobalAliases = append(item.GlobalAliases, types.StringValue(alias))
+		}
+		for _, alias := range bucket.LocalAliases {
+			item.LocalAliases = append(item.LocalAliases, LocalAliasModel{
+				AccessKeyID: types.StringValue(alias.AccessKeyID),
+				Alias:       types.StringValue(alias.Alias),
+			})
+		}
+		data.Buckets = append(data.Buckets, item)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func bucketHasAliasPrefix(bucket client.BucketListItem, prefix string) bool {
+	if prefix == "" {
+		return true
+	}
+	for _, alias := range bucket.GlobalAliases {
+		if strings.HasPrefix(alias, prefix) {
+			return true
+		}
+	}
+	for _, alias := range bucket.LocalAliases {
+		if strings.HasPrefix(alias.Alias, prefix) {
+			return true
+		}
+	}
+	return false
+}
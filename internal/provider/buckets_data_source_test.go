@@ -0,0 +1,62 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestAccBucketsDataSource(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: garage + testAccBucketsDataSourceConfig(),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(
+						"data.garage_buckets.all",
+						tfjsonpath.New("buckets"),
+						knownvalue.ListSizeExact(2),
+					),
+					statecheck.ExpectKnownValue(
+						"data.garage_buckets.prefixed",
+						tfjsonpath.New("buckets"),
+						knownvalue.ListExact([]knownvalue.Check{
+							knownvalue.ObjectPartial(map[string]knownvalue.Check{
+								"global_aliases": knownvalue.ListExact([]knownvalue.Check{
+									knownvalue.StringExact("bongo"),
+								}),
+							}),
+						}),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccBucketsDataSourceConfig() string {
+	return `
+resource "garage_bucket" "bongo" {
+	name = "bongo"
+}
+resource "garage_bucket" "apple" {
+	name = "apple"
+}
+data "garage_buckets" "all" {
+	depends_on = [garage_bucket.bongo, garage_bucket.apple]
+}
+data "garage_buckets" "prefixed" {
+	prefix = "bon"
+	depends_on = [garage_bucket.bongo, garage_bucket.apple]
+}
+`
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
@@ -16,6 +18,7 @@ import (
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PermissionResource{}
 var _ resource.ResourceWithImportState = &PermissionResource{}
+var _ resource.ResourceWithModifyPlan = &PermissionResource{}
 
 func NewPermissionResource() resource.Resource {
 	return &PermissionResource{}
@@ -31,6 +34,7 @@ type PermissionResourceModel struct {
 	ID          types.String `tfsdk:"id"`
 	AccessKeyID types.String `tfsdk:"access_key_id"`
 	BucketID    types.String `tfsdk:"bucket_id"`
+	BucketAlias types.String `tfsdk:"bucket_alias"`
 	Owner       types.Bool   `tfsdk:"owner"`
 	Read        types.Bool   `tfsdk:"read"`
 	Write       types.Bool   `tfsdk:"write"`
@@ -55,15 +59,30 @@ func (r *PermissionResource) Schema(
 			"id": schema.StringAttribute{
 				MarkdownDescription: "The id of the permission in format {accessKeyId}:{bucketId}",
 				Computed:            true,
-				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"access_key_id": schema.StringAttribute{
 				MarkdownDescription: "The access key id",
 				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
 			},
 			"bucket_id": schema.StringAttribute{
-				MarkdownDescription: "The bucket id",
-				Required:            true,
+				MarkdownDescription: "The bucket id, exactly one of `bucket_id` or `bucket_alias` must be set",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"bucket_alias": schema.StringAttribute{
+				MarkdownDescription: "A global alias of the bucket, used to look up the bucket id. Changing it only replaces " +
+					"the permission when the alias refers to a different bucket",
+				Optional: true,
 			},
 			"owner": schema.BoolAttribute{
 				MarkdownDescription: "Whether the key is the owner of the bucket",
@@ -122,6 +141,29 @@ func (r *PermissionResource) Create(
 		return
 	}
 
+	// The plan can keep bucket_id from the prior state when the permission is
+	// replaced, so check what is actually configured
+	var config PermissionResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if config.BucketID.IsNull() == config.BucketAlias.IsNull() {
+		resp.Diagnostics.AddError(
+			"invalid input",
+			"exactly one of bucket_id or bucket_alias must be set",
+		)
+		return
+	}
+	if !data.BucketAlias.IsNull() {
+		bucket, err := r.client.GetBucketByGlobalAlias(ctx, data.BucketAlias.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("could not get bucket", err.Error())
+			return
+		}
+		data.BucketID = types.StringValue(bucket.ID)
+	}
+
 	perms, err := r.client.CreatePermission(ctx, client.CreatePermissionRequest{
 		AccessKeyID: data.AccessKeyID.ValueString(),
 		BucketID:    data.BucketID.ValueString(),
@@ -167,6 +209,10 @@ func (r *PermissionResource) Read(
 	}
 
 	perms, err := r.client.GetPermissions(ctx, spl[0], spl[1])
+	if client.IsNotFound(err) {
+		resp.State.RemoveResource(ctx)
+		return
+	}
 	if err != nil {
 		resp.Diagnostics.AddError("could not get permissions", err.Error())
 		return
@@ -192,6 +238,29 @@ func (r *PermissionResource) Update(
 		return
 	}
 
+	// The plan keeps the bucket id when the alias didn't exist yet, i.e. the
+	// bucket is renamed in the same apply, so check it now
+	if !data.BucketAlias.IsNull() {
+		bucket, err := r.client.GetBucketByGlobalAlias(ctx, data.BucketAlias.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("could not get bucket", err.Error())
+			return
+		}
+		if bucket.ID != data.BucketID.ValueString() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("bucket_alias"),
+				"bucket alias refers to a different bucket",
+				fmt.Sprintf(
+					"bucket_alias %s now refers to bucket %s instead of %s, replace the permission to move it to the new bucket",
+					data.BucketAlias.ValueString(),
+					bucket.ID,
+					data.BucketID.ValueString(),
+				),
+			)
+			return
+		}
+	}
+
 	perms, err := r.client.UpdatePermission(ctx, client.CreatePermissionRequest{
 		AccessKeyID: data.AccessKeyID.ValueString(),
 		BucketID:    data.BucketID.ValueString(),
@@ -212,6 +281,55 @@ func (r *PermissionResource) Update(
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// ModifyPlan resolves bucket_alias to the bucket id, so the permission is only
+// replaced when the alias moves to a different bucket and not when the bucket
+// is renamed.
+func (r *PermissionResource) ModifyPlan(
+	ctx context.Context,
+	req resource.ModifyPlanRequest,
+	resp *resource.ModifyPlanResponse,
+) {
+	// Nothing to resolve when destroying, or before the provider is configured
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var plan PermissionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if plan.BucketAlias.IsNull() || plan.BucketAlias.IsUnknown() {
+		return
+	}
+
+	bucket, err := r.client.GetBucketByGlobalAlias(ctx, plan.BucketAlias.ValueString())
+	if client.IsNotFound(err) {
+		// The alias can be added later in the apply, so it is checked again
+		// when the permission is created or updated
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("could not get bucket", err.Error())
+		return
+	}
+
+	bucketID := types.StringValue(bucket.ID)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("bucket_id"), bucketID)...)
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state PermissionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !state.BucketID.Equal(bucketID) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("bucket_id"))
+	}
+}
+
 func mapPermsToData(data *PermissionResourceModel, perms *client.Permission) {
 	data.ID = types.StringValue(fmt.Sprintf("%s:%s", perms.AccessKeyID, perms.BucketID))
 	data.AccessKeyID = types.StringValue(perms.AccessKeyID)
@@ -234,6 +352,16 @@ func (r *PermissionResource) Delete(
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	err := r.client.DeletePermission(ctx, data.AccessKeyID.ValueString(), data.BucketID.ValueString())
+	// The key or bucket has already been deleted, so there is nothing to revoke
+	if client.IsNotFound(err) {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("could not delete permissions", err.Error())
+		return
+	}
 }
 
 func (r *PermissionResource) ImportState(
@@ -241,5 +369,39 @@ func (r *PermissionResource) ImportState(
 	req resource.ImportStateRequest,
 	resp *resource.ImportStateResponse,
 ) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	alias, ok := strings.CutPrefix(req.ID, "alias/")
+	if !ok {
+		spl := strings.Split(req.ID, ":")
+		if len(spl) != 2 || spl[0] == "" || spl[1] == "" {
+			resp.Diagnostics.AddError(
+				"invalid import id",
+				fmt.Sprintf(
+					"needs id in format {keyId}:{bucketId} or alias/{bucketAlias}:{keyId}, got %s",
+					req.ID,
+				),
+			)
+			return
+		}
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	alias, keyID, ok := strings.Cut(alias, ":")
+	if !ok || alias == "" || keyID == "" || strings.Contains(keyID, ":") {
+		resp.Diagnostics.AddError(
+			"invalid import id",
+			fmt.Sprintf("needs id in format alias/{bucketAlias}:{keyId}, got %s", req.ID),
+		)
+		return
+	}
+	bucket, err := r.client.GetBucketByGlobalAlias(ctx, alias)
+	if err != nil {
+		resp.Diagnostics.AddError("could not get bucket", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(
+		resp.State.SetAttribute(ctx, path.Root("id"), fmt.Sprintf("%s:%s", keyID, bucket.ID))...,
+	)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("bucket_alias"), alias)...)
 }
@@ -2,11 +2,15 @@ package provider
 
 import (
 	"fmt"
+	"regexp"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-testing/compare"
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
 	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
 	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
@@ -87,3 +91,156 @@ resource "garage_permission" "test" {
 }
 `, owner, read, write)
 }
+
+func TestAccPermissionResourceBucketAlias(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: garage + testAccPermissionResourceBucketAliasConfig("bongo"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"garage_permission.test",
+						tfjsonpath.New("bucket_id"),
+						"garage_bucket.test",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_permission.test",
+						tfjsonpath.New("bucket_alias"),
+						knownvalue.StringExact("bongo"),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_permission.test",
+						tfjsonpath.New("read"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+			// Import by the bucket alias
+			{
+				ResourceName: "garage_permission.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					key := s.RootModule().Resources["garage_access_key.test"].Primary.ID
+					return fmt.Sprintf("alias/bongo:%s", key), nil
+				},
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "garage_permission.test",
+				ImportState:   true,
+				ImportStateId: "alias/bongo",
+				ExpectError:   regexp.MustCompile("invalid import id"),
+			},
+			// Import by the bucket id, which doesn't know the alias
+			{
+				ResourceName: "garage_permission.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					key := s.RootModule().Resources["garage_access_key.test"].Primary.ID
+					bucket := s.RootModule().Resources["garage_bucket.test"].Primary.ID
+					return fmt.Sprintf("%s:%s", key, bucket), nil
+				},
+				ImportStatePersist: true,
+			},
+			// Setting the alias after the import doesn't replace the permission
+			{
+				Config: garage + testAccPermissionResourceBucketAliasConfig("bongo"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_permission.test", plancheck.ResourceActionUpdate),
+					},
+				},
+			},
+			// Renaming the bucket updates the alias in place
+			{
+				Config: garage + testAccPermissionResourceBucketAliasConfig("bingo"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("garage_permission.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.CompareValuePairs(
+						"garage_permission.test",
+						tfjsonpath.New("bucket_id"),
+						"garage_bucket.test",
+						tfjsonpath.New("id"),
+						compare.ValuesSame(),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_permission.test",
+						tfjsonpath.New("bucket_alias"),
+						knownvalue.StringExact("bingo"),
+					),
+					statecheck.ExpectKnownValue(
+						"garage_permission.test",
+						tfjsonpath.New("read"),
+						knownvalue.Bool(true),
+					),
+				},
+			},
+		},
+	})
+}
+
+func testAccPermissionResourceBucketAliasConfig(name string) string {
+	return fmt.Sprintf(`
+resource "garage_bucket" "test" {
+	name = "%s"
+}
+resource "garage_access_key" "test" {
+	name = "apple"
+}
+resource "garage_permission" "test" {
+	access_key_id = garage_access_key.test.id
+	bucket_alias = garage_bucket.test.name
+	read = true
+}
+`, name)
+}
+
+func TestAccPermissionResourceDelete(t *testing.T) {
+	garage, c, cancel := garageWithClient(t)
+	defer cancel()
+
+	var keyID, bucketID string
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: garage + testAccPermissionResourceConfig(true, true, true),
+				Check: func(s *terraform.State) error {
+					keyID = s.RootModule().Resources["garage_access_key.test"].Primary.ID
+					bucketID = s.RootModule().Resources["garage_bucket.test"].Primary.ID
+					return nil
+				},
+			},
+			// Remove the permission but keep the key and bucket
+			{
+				Config: garage + testAccPermissionResourceKeyAndBucketConfig(),
+				Check: func(s *terraform.State) error {
+					return testAccCheckNoAccess(t, c, keyID, bucketID)
+				},
+			},
+		},
+	})
+}
+
+func testAccPermissionResourceKeyAndBucketConfig() string {
+	return `
+resource "garage_bucket" "test" {
+	name = "bongo"
+}
+resource "garage_access_key" "test" {
+	name = "apple"
+}
+`
+}
@@ -3,16 +3,24 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
 	"slices"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
 )
 
@@ -31,9 +39,27 @@ type GarageProvider struct {
 
 // GarageProviderModel describes the provider data model.
 type GarageProviderModel struct {
-	Host   types.String `tfsdk:"host"`
-	Scheme types.String `tfsdk:"scheme"`
-	Token  types.String `tfsdk:"token"`
+	Endpoint  types.String `tfsdk:"endpoint"`
+	Endpoints types.List   `tfsdk:"endpoints"`
+	Host      types.String `tfsdk:"host"`
+	Scheme    types.String `tfsdk:"scheme"`
+	Token     types.String `tfsdk:"token"`
+	TokenFile types.String `tfsdk:"token_file"`
+
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.String `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.String `tfsdk:"retry_wait_max"`
+
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ClientCert         types.String `tfsdk:"client_cert"`
+	ClientKey          types.String `tfsdk:"client_key"`
+	TLSServerName      types.String `tfsdk:"tls_server_name"`
+	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+
+	RequestTimeout types.String `tfsdk:"request_timeout"`
+	HTTPProxy      types.String `tfsdk:"http_proxy"`
+	Headers        types.Map    `tfsdk:"headers"`
 }
 
 func (p *GarageProvider) Metadata(
@@ -52,17 +78,82 @@ func (p *GarageProvider) Schema(
 ) {
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The full url of the garage api including any path prefix, i.e.: https://infra.example.com/garage-admin, " +
+					"takes precedence over `host` and `scheme`, defaults to the `GARAGE_ENDPOINT` environment variable when `host` isn't set",
+				Optional: true,
+			},
+			"endpoints": schema.ListAttribute{
+				MarkdownDescription: "The full urls of several garage nodes, requests are sent to the first one that can be reached " +
+					"and unreachable nodes are skipped for the rest of the run, conflicts with `endpoint` and `host`",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
 			"host": schema.StringAttribute{
-				MarkdownDescription: "Hostname/ip to access the garage api",
-				Required:            true,
+				MarkdownDescription: "Hostname/ip to access the garage api, defaults to the `GARAGE_HOST` environment variable",
+				Optional:            true,
 			},
 			"scheme": schema.StringAttribute{
-				MarkdownDescription: "The scheme to use, i.e.: http or https",
+				MarkdownDescription: "The scheme to use, i.e.: http or https, defaults to the `GARAGE_SCHEME` environment variable or https",
 				Optional:            true,
 			},
 			"token": schema.StringAttribute{
-				MarkdownDescription: "The token to authenticate with the garage api",
-				Required:            true,
+				MarkdownDescription: "The token to authenticate with the garage api, defaults to the `GARAGE_ADMIN_TOKEN` environment variable",
+				Optional:            true,
+			},
+			"token_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the token, re-read every time the provider is configured",
+				Optional:            true,
+			},
+			"max_retries": schema.Int64Attribute{
+				MarkdownDescription: "The number of times to retry idempotent requests that fail with a connection error, 429 or 5xx, defaults to 3",
+				Optional:            true,
+			},
+			"retry_wait_min": schema.StringAttribute{
+				MarkdownDescription: "The minimum duration to wait before retrying a request, i.e.: 500ms, defaults to 1s",
+				Optional:            true,
+			},
+			"retry_wait_max": schema.StringAttribute{
+				MarkdownDescription: "The maximum duration to wait before retrying a request, defaults to 30s",
+				Optional:            true,
+			},
+			"ca_cert_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded CA bundle used to verify the garage api",
+				Optional:            true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA bundle used to verify the garage api",
+				Optional:            true,
+			},
+			"client_cert": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client certificate for mutual TLS, requires `client_key`",
+				Optional:            true,
+			},
+			"client_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded client private key for mutual TLS, requires `client_cert`",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"tls_server_name": schema.StringAttribute{
+				MarkdownDescription: "The server name used to verify the garage api certificate, defaults to the host",
+				Optional:            true,
+			},
+			"insecure_skip_verify": schema.BoolAttribute{
+				MarkdownDescription: "Skip verifying the garage api certificate",
+				Optional:            true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "The maximum duration of a single request to the garage api, i.e.: 30s, defaults to 1m",
+				Optional:            true,
+			},
+			"http_proxy": schema.StringAttribute{
+				MarkdownDescription: "The url of a proxy to send requests through, defaults to the `HTTPS_PROXY`/`HTTP_PROXY` environment variables",
+				Optional:            true,
+			},
+			"headers": schema.MapAttribute{
+				MarkdownDescription: "Extra headers to send with every request, i.e. for an auth proxy in front of the garage api",
+				ElementType:         types.StringType,
+				Optional:            true,
 			},
 		},
 	}
@@ -70,6 +161,9 @@ func (p *GarageProvider) Schema(
 
 type setupData struct {
 	client *client.Client
+	// garageVersion is the version reported by the cluster, empty when the
+	// token can't read the cluster status.
+	garageVersion string
 }
 
 func (p *GarageProvider) Configure(
@@ -85,35 +179,361 @@ func (p *GarageProvider) Configure(
 		return
 	}
 
-	scheme := data.Scheme.ValueString()
+	for _, attr := range []struct {
+		name  string
+		value types.String
+	}{
+		{"endpoint", data.Endpoint},
+		{"host", data.Host},
+		{"scheme", data.Scheme},
+		{"token", data.Token},
+		{"token_file", data.TokenFile},
+	} {
+		if attr.value.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attr.name),
+				fmt.Sprintf("unknown %s value", attr.name),
+				fmt.Sprintf(
+					"%s must be known when configuring the provider, set it statically or use the environment variable fallback",
+					attr.name,
+				),
+			)
+		}
+	}
+	if data.Endpoints.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoints"),
+			"unknown endpoints value",
+			"endpoints must be known when configuring the provider",
+		)
+	}
+	if !data.Endpoints.IsNull() && (!data.Endpoint.IsNull() || !data.Host.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoints"),
+			"conflicting endpoint configuration",
+			"endpoints can't be set with endpoint or host",
+		)
+	}
+	if !data.Token.IsNull() && !data.TokenFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token_file"),
+			"conflicting token configuration",
+			"only one of token or token_file can be set",
+		)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	endpoints := []string{}
+	if !data.Endpoints.IsNull() {
+		resp.Diagnostics.Append(data.Endpoints.ElementsAs(ctx, &endpoints, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	// The environment is only a fallback, so a configured host isn't
+	// overridden by GARAGE_ENDPOINT
+	endpoint := data.Endpoint.ValueString()
+	host := data.Host.ValueString()
+	if data.Endpoint.IsNull() && data.Host.IsNull() {
+		endpoint = os.Getenv("GARAGE_ENDPOINT")
+		host = os.Getenv("GARAGE_HOST")
+	}
+	scheme := stringOrEnv(data.Scheme, "GARAGE_SCHEME")
 	if scheme == "" {
 		scheme = "https"
 	}
 
-	if !slices.Contains([]string{"http", "https"}, scheme) {
+	token := data.Token.ValueString()
+	if !data.TokenFile.IsNull() {
+		by, err := os.ReadFile(data.TokenFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("token_file"),
+				"could not read token file",
+				err.Error(),
+			)
+			return
+		}
+		token = strings.TrimSpace(string(by))
+	}
+	if token == "" && data.TokenFile.IsNull() {
+		token = os.Getenv("GARAGE_ADMIN_TOKEN")
+	}
+
+	missing := []string{}
+	if endpoint == "" && host == "" && len(endpoints) == 0 {
+		missing = append(
+			missing,
+			"endpoint: set the endpoint, endpoints or host attribute, or the GARAGE_ENDPOINT or GARAGE_HOST environment variable",
+		)
+	}
+	if token == "" {
+		missing = append(
+			missing,
+			"token: set the token or token_file attribute, or the GARAGE_ADMIN_TOKEN environment variable",
+		)
+	}
+	if len(missing) > 0 {
+		resp.Diagnostics.AddError(
+			"missing garage api configuration",
+			fmt.Sprintf("could not find a value for:\n- %s", strings.Join(missing, "\n- ")),
+		)
+		return
+	}
+
+	if len(endpoints) > 0 {
+		for i, e := range endpoints {
+			parsed, err := parseEndpoint(e)
+			if err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("endpoints").AtListIndex(i),
+					"invalid endpoints value",
+					err.Error(),
+				)
+				continue
+			}
+			endpoints[i] = parsed
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		if endpoint == "" {
+			if !slices.Contains([]string{"http", "https"}, scheme) {
+				resp.Diagnostics.AddError(
+					"invalid scheme value",
+					fmt.Sprintf("scheme must be one of: http, https, got %s", scheme),
+				)
+				return
+			}
+			endpoint = fmt.Sprintf("%s://%s", scheme, host)
+		}
+		parsed, err := parseEndpoint(endpoint)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("endpoint"), "invalid endpoint value", err.Error())
+			return
+		}
+		endpoints = []string{parsed}
+	}
+
+	retry := client.DefaultRetryConfig
+	if !data.MaxRetries.IsNull() {
+		if data.MaxRetries.ValueInt64() < 0 {
+			resp.Diagnostics.AddError(
+				"invalid max_retries value",
+				fmt.Sprintf("max_retries must not be negative, got %d", data.MaxRetries.ValueInt64()),
+			)
+			return
+		}
+		retry.MaxRetries = int(data.MaxRetries.ValueInt64())
+	}
+	retry.WaitMin = parseDuration(resp, "retry_wait_min", data.RetryWaitMin, retry.WaitMin)
+	retry.WaitMax = parseDuration(resp, "retry_wait_max", data.RetryWaitMax, retry.WaitMax)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if retry.WaitMin > retry.WaitMax {
 		resp.Diagnostics.AddError(
-			"invalid scheme value",
-			fmt.Sprintf("scheme must be one of: http, https, got %s", scheme),
+			"invalid retry_wait_min value",
+			fmt.Sprintf(
+				"retry_wait_min must not be greater than retry_wait_max, got %s > %s",
+				retry.WaitMin,
+				retry.WaitMax,
+			),
 		)
 		return
 	}
 
+	tlsConfig, err := client.TLSConfig{
+		CACertFile:         data.CACertFile.ValueString(),
+		CACertPEM:          data.CACertPEM.ValueString(),
+		ClientCertPEM:      data.ClientCert.ValueString(),
+		ClientKeyPEM:       data.ClientKey.ValueString(),
+		ServerName:         data.TLSServerName.ValueString(),
+		InsecureSkipVerify: data.InsecureSkipVerify.ValueBool(),
+	}.Build()
+	if err != nil {
+		resp.Diagnostics.AddError("invalid tls configuration", err.Error())
+		return
+	}
+
+	opts := []client.Option{
+		client.WithRetry(retry),
+		client.WithTLSConfig(tlsConfig),
+		client.WithTimeout(parseDuration(resp, "request_timeout", data.RequestTimeout, time.Minute)),
+		client.WithFailover(endpoints[1:]...),
+	}
+	if !data.HTTPProxy.IsNull() {
+		proxy, err := url.Parse(data.HTTPProxy.ValueString())
+		if err != nil || proxy.Scheme == "" || proxy.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("http_proxy"),
+				"invalid http_proxy value",
+				"http_proxy must be a url, i.e.: http://proxy:3128",
+			)
+		} else {
+			opts = append(opts, client.WithProxy(proxy))
+		}
+	}
+	if !data.Headers.IsNull() {
+		headers := map[string]string{}
+		resp.Diagnostics.Append(data.Headers.ElementsAs(ctx, &headers, false)...)
+		opts = append(opts, client.WithHeaders(headers))
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c := client.New(endpoints[0], token, opts...)
+	checkConnection(ctx, resp, c, endpoints)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	setup := setupData{
-		client: client.New(
-			fmt.Sprintf("%s://%s", scheme, data.Host.ValueString()),
-			data.Token.ValueString(),
-		),
+		client:        c,
+		garageVersion: garageVersion(ctx, c),
 	}
 
 	resp.DataSourceData = setup
 	resp.ResourceData = setup
 }
 
+// checkConnection checks the token works against the garage api, adding an
+// error to the response when garage can't be used.
+func checkConnection(
+	ctx context.Context,
+	resp *provider.ConfigureResponse,
+	c *client.Client,
+	endpoints []string,
+) {
+	_, err := c.GetCurrentAdminToken(ctx)
+	if err == nil {
+		return
+	}
+
+	endpoint := strings.Join(endpoints, ", ")
+	apiErr := &client.APIError{}
+	switch {
+	case client.IsUnauthorized(err):
+		resp.Diagnostics.AddError(
+			"invalid garage admin token",
+			fmt.Sprintf("garage rejected the admin token, check the token is correct and hasn't expired: %s", err),
+		)
+	case errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusNotFound) &&
+		apiErr.Code != "":
+		// Only garage sends an error code, so garage doesn't know the v2 path.
+		// Either it is too old or the path prefix is wrong
+		resp.Diagnostics.AddError(
+			"unsupported garage version",
+			fmt.Sprintf(
+				"the garage admin api at %s doesn't support the v2 api, check the endpoint and any path prefix are "+
+					"correct, the provider requires garage v2.0.0 or newer: %s",
+				endpoint,
+				err,
+			),
+		)
+	case errors.As(err, &apiErr) &&
+		(apiErr.StatusCode == http.StatusBadRequest || apiErr.StatusCode == http.StatusNotFound):
+		resp.Diagnostics.AddError(
+			"garage admin api not found",
+			fmt.Sprintf(
+				"%s doesn't look like the garage admin api, check the endpoint and any path prefix are correct: %s",
+				endpoint,
+				err,
+			),
+		)
+	case errors.As(err, &apiErr):
+		resp.Diagnostics.AddError("could not check the garage admin api", err.Error())
+	default:
+		resp.Diagnostics.AddError(
+			"could not connect to garage",
+			fmt.Sprintf(
+				"could not reach the garage admin api at %s, check the endpoint is correct and garage is running: %s",
+				endpoint,
+				err,
+			),
+		)
+	}
+}
+
+// garageVersion returns the version of garage the cluster is running, or an
+// empty string when it can't be read.
+func garageVersion(ctx context.Context, c *client.Client) string {
+	// Tokens with a limited scope might not be able to read the status, which
+	// the provider doesn't need
+	status, err := c.GetClusterStatus(ctx)
+	if err != nil {
+		tflog.Warn(ctx, "could not get the garage version", map[string]any{"error": err.Error()})
+		return ""
+	}
+	version := status.Version()
+	tflog.Info(ctx, "connected to garage", map[string]any{"version": version})
+	return version
+}
+
+// parseEndpoint validates the url of the garage api, which can include a path
+// prefix when it is served behind a reverse proxy.
+func parseEndpoint(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if !slices.Contains([]string{"http", "https"}, u.Scheme) {
+		return "", fmt.Errorf("scheme must be one of: http, https, got %s", u.Scheme)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("missing host in %s", u.Redacted())
+	}
+	if u.User != nil || u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("must not contain credentials, a query or a fragment")
+	}
+	return strings.TrimRight(u.String(), "/"), nil
+}
+
+// stringOrEnv returns the attribute value, falling back to the environment
+// variable when it isn't set.
+func stringOrEnv(value types.String, env string) string {
+	if !value.IsNull() {
+		return value.ValueString()
+	}
+	return os.Getenv(env)
+}
+
+// parseDuration parses an optional duration attribute, adding an error to the
+// response when it is invalid.
+func parseDuration(
+	resp *provider.ConfigureResponse,
+	name string,
+	value types.String,
+	fallback time.Duration,
+) time.Duration {
+	if value.IsNull() {
+		return fallback
+	}
+	d, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("invalid %s value", name),
+			fmt.Sprintf("%s must be a duration, i.e.: 1s, got %s", name, value.ValueString()),
+		)
+		return fallback
+	}
+	return d
+}
+
 func (p *GarageProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewBucketResource,
+		NewBucketAliasResource,
+		NewBucketLocalAliasResource,
 		NewAccessKeyResource,
 		NewPermissionResource,
+		NewBucketPermissionsResource,
+		NewAccessKeyBucketsResource,
 	}
 }
 
@@ -124,7 +544,12 @@ func (p *GarageProvider) EphemeralResources(
 }
 
 func (p *GarageProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{}
+	return []func() datasource.DataSource{
+		NewBucketDataSource,
+		NewBucketsDataSource,
+		NewAccessKeyDataSource,
+		NewAccessKeysDataSource,
+	}
 }
 
 func (p *GarageProvider) Functions(ctx context.Context) []func() function.Function {
@@ -6,23 +6,26 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/henrywhitaker3/terraform-provider-garage/internal/client"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/wait"
 )
 
-func providerConfig(ip string, port int, token string) string {
+func providerConfig(host string, token string) string {
 	return fmt.Sprintf(`
 provider "garage" {
 	host = "%s"
 	scheme = "http"
 	token = "%s"
-}`, fmt.Sprintf("%s:%d", ip, port), token)
+}`, host, token)
 }
 
 const (
@@ -55,7 +58,25 @@ admin_token = "EVCNqzJY4StaQ7RGZ+triyhK6GCzgLNrhlqSvTMVyrI="
 metrics_token = "neFhPdBSRjcrfTW4LKcnTMqJQAY6vOII+qdVQZK/Dtw="`
 )
 
+const garageAdminToken = "EVCNqzJY4StaQ7RGZ+triyhK6GCzgLNrhlqSvTMVyrI="
+
 func garage(t *testing.T) (string, context.CancelFunc) {
+	config, _, cancel := garageWithClient(t)
+	return config, cancel
+}
+
+// garageWithClient starts garage and also returns a client for it, to check
+// changes terraform made outside of its state.
+func garageWithClient(t *testing.T) (string, *client.Client, context.CancelFunc) {
+	host, cancel := garageHost(t)
+	return providerConfig(host, garageAdminToken),
+		client.New(fmt.Sprintf("http://%s", host), garageAdminToken),
+		cancel
+}
+
+// garageHost starts garage and returns the host:port of its admin api, for
+// tests that configure the provider themselves.
+func garageHost(t *testing.T) (string, context.CancelFunc) {
 	configPath := filepath.Join(t.TempDir(), "garage.toml")
 	require.Nil(
 		t,
@@ -127,13 +148,9 @@ func garage(t *testing.T) (string, context.CancelFunc) {
 	port, err := container.MappedPort(ctx, "3903")
 	require.Nil(t, err)
 
-	return providerConfig(
-			"127.0.0.1",
-			port.Int(),
-			"EVCNqzJY4StaQ7RGZ+triyhK6GCzgLNrhlqSvTMVyrI=",
-		), func() {
-			_ = container.Terminate(ctx)
-		}
+	return fmt.Sprintf("127.0.0.1:%d", port.Int()), func() {
+		_ = container.Terminate(ctx)
+	}
 }
 
 // testAccProtoV6ProviderFactories is used to instantiate a provider during acceptance testing.
@@ -143,6 +160,141 @@ var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServe
 	"garage": providerserver.NewProtocol6WithError(New("test")()),
 }
 
+func TestAccProviderInvalidToken(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(garage, garageAdminToken, "bongo", 1) + testAccProviderConfig(),
+				ExpectError: regexp.MustCompile("invalid garage admin token"),
+			},
+		},
+	})
+}
+
+func TestAccProviderUnreachable(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "garage" {
+	endpoint = "http://127.0.0.1:1"
+	token = "bongo"
+	max_retries = 0
+}` + testAccProviderConfig(),
+				ExpectError: regexp.MustCompile("could not connect to garage"),
+			},
+		},
+	})
+}
+
+func TestAccProviderEnvironment(t *testing.T) {
+	host, cancel := garageHost(t)
+	defer cancel()
+	t.Setenv("GARAGE_ENDPOINT", "")
+	t.Setenv("GARAGE_HOST", host)
+	t.Setenv("GARAGE_SCHEME", "http")
+	t.Setenv("GARAGE_ADMIN_TOKEN", garageAdminToken)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `provider "garage" {}` + testAccProviderConfig(),
+			},
+		},
+	})
+}
+
+func TestAccProviderHostOverridesEndpointEnvironment(t *testing.T) {
+	garage, cancel := garage(t)
+	defer cancel()
+	t.Setenv("GARAGE_ENDPOINT", "http://127.0.0.1:1")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: garage + testAccProviderConfig(),
+			},
+		},
+	})
+}
+
+func TestAccProviderTokenFile(t *testing.T) {
+	host, cancel := garageHost(t)
+	defer cancel()
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.Nil(t, os.WriteFile(tokenFile, []byte(garageAdminToken+"\n"), 0600))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "garage" {
+	host = "%s"
+	scheme = "http"
+	token_file = "%s"
+}`, host, tokenFile) + testAccProviderConfig(),
+			},
+		},
+	})
+}
+
+func TestAccProviderConflictingToken(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.Nil(t, os.WriteFile(tokenFile, []byte("bongo"), 0600))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "garage" {
+	host = "127.0.0.1:3903"
+	token = "bongo"
+	token_file = "%s"
+}`, tokenFile) + testAccProviderConfig(),
+				ExpectError: regexp.MustCompile("conflicting token configuration"),
+			},
+		},
+	})
+}
+
+func TestAccProviderMissingConfig(t *testing.T) {
+	t.Setenv("GARAGE_ENDPOINT", "")
+	t.Setenv("GARAGE_HOST", "")
+	t.Setenv("GARAGE_ADMIN_TOKEN", "")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      `provider "garage" {}` + testAccProviderConfig(),
+				ExpectError: regexp.MustCompile("(?s)missing garage api configuration.*endpoint:.*token:"),
+			},
+		},
+	})
+}
+
+func testAccProviderConfig() string {
+	return `
+data "garage_access_keys" "test" {}
+`
+}
+
 func testAccPreCheck(t *testing.T) {
 	// You can add code here to run prior to any test case execution, for example assertions
 	// about the appropriate environment variables being set are common to see in a pre-check